@@ -0,0 +1,336 @@
+package bitcoin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+)
+
+// BitcoinRPCClient talks to the JSON-RPC interface of Bitcoin Core, or of a fork of it such as Litecoin or Dogecoin.
+// Requests are sent as JSON-RPC 1.0, which every version understands. Credentials are taken from the endpoint URL.
+type BitcoinRPCClient struct {
+	address  string
+	username string
+	password string
+	client   *http.Client
+}
+
+func NewBitcoinRPCClient(endpoint string) (*BitcoinRPCClient, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+	c := &BitcoinRPCClient{client: &http.Client{}}
+	if u.User != nil {
+		c.username = u.User.Username()
+		c.password, _ = u.User.Password()
+		u.User = nil
+	}
+	c.address = u.String()
+	return c, nil
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// RPCError is an error returned by the node for a single request.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+// batchCall is a call in a batch. Err is set if that call failed.
+type batchCall struct {
+	Method string
+	Params []interface{}
+	Result interface{}
+	Err    error
+}
+
+func (c *BitcoinRPCClient) post(ctx context.Context, body interface{}) ([]byte, error) {
+	bz, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.address, bytes.NewReader(bz))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("rpc authentication failed: %s", resp.Status)
+	}
+	// older nodes answer failed single requests with a 500 and the error in the body
+	return io.ReadAll(resp.Body)
+}
+
+// call sends a single request and decodes its result into result.
+func (c *BitcoinRPCClient) call(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	bz, err := c.post(ctx, rpcRequest{JSONRPC: "1.0", Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	var resp rpcResponse
+	if err := json.Unmarshal(bz, &resp); err != nil {
+		return fmt.Errorf("%s: failed to decode response: %w", method, err)
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("%s: %w", method, resp.Error)
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+
+// batch sends the calls as a single JSON-RPC batch. An error is only returned if the batch as a whole failed.
+func (c *BitcoinRPCClient) batch(ctx context.Context, calls []*batchCall) error {
+	if len(calls) == 0 {
+		return nil
+	}
+	requests := make([]rpcRequest, len(calls))
+	for i, call := range calls {
+		params := call.Params
+		if params == nil {
+			params = []interface{}{}
+		}
+		requests[i] = rpcRequest{JSONRPC: "1.0", ID: i, Method: call.Method, Params: params}
+	}
+	bz, err := c.post(ctx, requests)
+	if err != nil {
+		return err
+	}
+	var responses []rpcResponse
+	if err := json.Unmarshal(bz, &responses); err != nil {
+		return fmt.Errorf("failed to decode batch response: %w", err)
+	}
+	answered := make([]bool, len(calls))
+	for _, resp := range responses {
+		if resp.ID < 0 || resp.ID >= len(calls) {
+			continue
+		}
+		call := calls[resp.ID]
+		answered[resp.ID] = true
+		switch {
+		case resp.Error != nil:
+			call.Err = resp.Error
+		default:
+			call.Err = json.Unmarshal(resp.Result, call.Result)
+		}
+	}
+	for i, ok := range answered {
+		if !ok {
+			calls[i].Err = errors.New("no response")
+		}
+	}
+	return nil
+}
+
+// MempoolInfo is the result of getmempoolinfo.
+type MempoolInfo struct {
+	Size  int   `json:"size"`
+	Bytes int64 `json:"bytes"` // sum of the virtual sizes of the txs
+	Usage int64 `json:"usage"`
+	// MempoolMinFee is the lowest fee rate the mempool accepts, in coins per kvB.
+	MempoolMinFee float64 `json:"mempoolminfee"`
+	// FullRBF is set when the node replaces txs that don't signal BIP125, the default since Bitcoin Core 28.
+	FullRBF bool `json:"fullrbf"`
+}
+
+// MempoolInfo calls getmempoolinfo.
+func (c *BitcoinRPCClient) MempoolInfo(ctx context.Context) (MempoolInfo, error) {
+	var info MempoolInfo
+	err := c.call(ctx, &info, "getmempoolinfo")
+	return info, err
+}
+
+// MempoolEntry is a tx in the mempool as returned by getrawmempool true and getmempoolentry. Amounts and sizes moved
+// between fields over versions, so use the methods, which work for old forks as well as current Bitcoin Core.
+type MempoolEntry struct {
+	VSize int64 `json:"vsize"`
+	// Size is the virtual size on nodes from before vsize was added.
+	Size int64 `json:"size"`
+	Time int64 `json:"time"`
+	// Height is the block height the tx entered the mempool at.
+	Height int64 `json:"height"`
+	Fees   *struct {
+		Base       float64 `json:"base"`
+		Modified   float64 `json:"modified"`
+		Ancestor   float64 `json:"ancestor"`
+		Descendant float64 `json:"descendant"`
+	} `json:"fees"`
+	// Fee is in coins, AncestorFees and DescendantFees in satoshis, on nodes from before fees was added.
+	Fee             float64  `json:"fee"`
+	AncestorFees    int64    `json:"ancestorfees"`
+	DescendantFees  int64    `json:"descendantfees"`
+	AncestorCount   int      `json:"ancestorcount"`
+	AncestorSize    int64    `json:"ancestorsize"`
+	DescendantCount int      `json:"descendantcount"`
+	DescendantSize  int64    `json:"descendantsize"`
+	Depends         []string `json:"depends"`
+	SpentBy         []string `json:"spentby"`
+	// Replaceable is set for txs signaling BIP125 replaceability.
+	Replaceable bool `json:"bip125-replaceable"`
+}
+
+// toSats converts an amount in coins to satoshis.
+func toSats(coins float64) int64 {
+	return int64(math.Round(coins * 1e8))
+}
+
+// VirtualSize returns the virtual size of the tx in vbytes.
+func (e *MempoolEntry) VirtualSize() int64 {
+	if e.VSize > 0 {
+		return e.VSize
+	}
+	return e.Size
+}
+
+// FeeSats returns the fee paid by the tx.
+func (e *MempoolEntry) FeeSats() int64 {
+	if e.Fees != nil {
+		return toSats(e.Fees.Base)
+	}
+	return toSats(e.Fee)
+}
+
+// AncestorFeeSats returns the fee paid by the tx and its unconfirmed ancestors.
+func (e *MempoolEntry) AncestorFeeSats() int64 {
+	if e.Fees != nil {
+		return toSats(e.Fees.Ancestor)
+	}
+	return e.AncestorFees
+}
+
+// FeeRate returns the fee rate of the tx alone, in sat/vB.
+func (e *MempoolEntry) FeeRate() float64 {
+	return feeRate(e.FeeSats(), e.VirtualSize())
+}
+
+// PackageFeeRate returns the fee rate of the tx with its unconfirmed ancestors, which is what miners select it by.
+func (e *MempoolEntry) PackageFeeRate() float64 {
+	if e.AncestorSize == 0 {
+		return e.FeeRate()
+	}
+	return feeRate(e.AncestorFeeSats(), e.AncestorSize)
+}
+
+func feeRate(sats, vsize int64) float64 {
+	if vsize <= 0 {
+		return 0
+	}
+	return float64(sats) / float64(vsize)
+}
+
+// RawMempool calls getrawmempool true, returning every mempool entry by txid.
+func (c *BitcoinRPCClient) RawMempool(ctx context.Context) (map[string]*MempoolEntry, error) {
+	var entries map[string]*MempoolEntry
+	err := c.call(ctx, &entries, "getrawmempool", true)
+	return entries, err
+}
+
+// MempoolTxIDs calls getrawmempool false, returning only the txids.
+func (c *BitcoinRPCClient) MempoolTxIDs(ctx context.Context) ([]string, error) {
+	var txids []string
+	err := c.call(ctx, &txids, "getrawmempool", false)
+	return txids, err
+}
+
+// Outpoint is an output spent by a tx.
+type Outpoint struct {
+	TxID string `json:"txid"`
+	Vout uint32 `json:"vout"`
+}
+
+// MempoolEntries calls getmempoolentry for every txid in a batch, along with getrawtransaction to learn the outputs
+// each tx spends. Txs that left the mempool in between are missing from the result.
+func (c *BitcoinRPCClient) MempoolEntries(ctx context.Context, txids []string) (map[string]*MempoolEntry, map[string][]Outpoint, error) {
+	type rawTx struct {
+		Vin []Outpoint `json:"vin"`
+	}
+	entries := make([]*MempoolEntry, len(txids))
+	raws := make([]*rawTx, len(txids))
+	calls := make([]*batchCall, 0, 2*len(txids))
+	for i, txid := range txids {
+		calls = append(calls,
+			&batchCall{Method: "getmempoolentry", Params: []interface{}{txid}, Result: &entries[i]},
+			// verbose as a number, which old forks require
+			&batchCall{Method: "getrawtransaction", Params: []interface{}{txid, 1}, Result: &raws[i]},
+		)
+	}
+	if err := c.batch(ctx, calls); err != nil {
+		return nil, nil, err
+	}
+
+	entryMap := make(map[string]*MempoolEntry, len(txids))
+	inputs := make(map[string][]Outpoint, len(txids))
+	for i, txid := range txids {
+		if calls[2*i].Err == nil && entries[i] != nil {
+			entryMap[txid] = entries[i]
+		}
+		if calls[2*i+1].Err == nil && raws[i] != nil {
+			var vin []Outpoint
+			for _, in := range raws[i].Vin {
+				// coinbase inputs have no txid
+				if in.TxID != "" {
+					vin = append(vin, in)
+				}
+			}
+			inputs[txid] = vin
+		}
+	}
+	return entryMap, inputs, nil
+}
+
+// Block is a block as returned by getblock with verbosity 1.
+type Block struct {
+	Hash              string   `json:"hash"`
+	Height            int64    `json:"height"`
+	Time              int64    `json:"time"`
+	Tx                []string `json:"tx"`
+	PreviousBlockHash string   `json:"previousblockhash"`
+}
+
+// BestBlockHash calls getbestblockhash.
+func (c *BitcoinRPCClient) BestBlockHash(ctx context.Context) (string, error) {
+	var hash string
+	err := c.call(ctx, &hash, "getbestblockhash")
+	return hash, err
+}
+
+// Block calls getblock with the txids of the block. Verbosity is passed as a bool, which all versions accept.
+func (c *BitcoinRPCClient) Block(ctx context.Context, hash string) (*Block, error) {
+	var block Block
+	if err := c.call(ctx, &block, "getblock", hash, true); err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
@@ -0,0 +1,99 @@
+package bitcoin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNode answers JSON-RPC 1.0 requests and batches from canned results, failing unknown methods the way
+// Bitcoin Core does: a 500 for single requests and an error entry within batches.
+func fakeNode(t *testing.T, results map[string]string) *httptest.Server {
+	answer := func(req rpcRequest) map[string]interface{} {
+		key := req.Method
+		if len(req.Params) > 0 {
+			if s, ok := req.Params[0].(string); ok {
+				key += " " + s
+			}
+		}
+		if result, ok := results[key]; ok {
+			return map[string]interface{}{"id": req.ID, "result": json.RawMessage(result), "error": nil}
+		}
+		return map[string]interface{}{"id": req.ID, "result": nil, "error": map[string]interface{}{"code": -5, "message": "not found"}}
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var raw json.RawMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&raw))
+		if raw[0] == '[' {
+			var reqs []rpcRequest
+			require.NoError(t, json.Unmarshal(raw, &reqs))
+			var resps []map[string]interface{}
+			for _, req := range reqs {
+				resps = append(resps, answer(req))
+			}
+			_ = json.NewEncoder(w).Encode(resps)
+			return
+		}
+		var req rpcRequest
+		require.NoError(t, json.Unmarshal(raw, &req))
+		resp := answer(req)
+		if resp["error"] != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestBitcoinRPCClient(t *testing.T) {
+	node := fakeNode(t, map[string]string{
+		"getmempoolinfo": `{"size":2,"bytes":350,"usage":2000,"mempoolminfee":0.00001,"fullrbf":true}`,
+		// current Bitcoin Core
+		"getmempoolentry aa": `{"vsize":200,"fees":{"base":0.00002,"modified":0.00002,"ancestor":0.00003,"descendant":0.00002},
+			"ancestorcount":2,"ancestorsize":300,"descendantcount":1,"descendantsize":200,"bip125-replaceable":false}`,
+		"getrawtransaction aa": `{"txid":"aa","vin":[{"txid":"p1","vout":0},{"txid":"p2","vout":3}]}`,
+		// a fork from before vsize and fees: size is the virtual size, ancestorfees is in satoshis
+		"getmempoolentry bb":   `{"size":150,"fee":0.0000075,"ancestorfees":750,"ancestorcount":1,"ancestorsize":150,"bip125-replaceable":true}`,
+		"getrawtransaction bb": `{"txid":"bb","vin":[{"coinbase":"03"}]}`,
+	})
+	defer node.Close()
+
+	client, err := NewBitcoinRPCClient("http://user:pass@" + node.Listener.Addr().String())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	info, err := client.MempoolInfo(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, info.Size)
+	require.True(t, info.FullRBF)
+
+	entries, inputs, err := client.MempoolEntries(ctx, []string{"aa", "bb", "gone"})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, 10.0, entries["aa"].FeeRate())
+	require.Equal(t, 10.0, entries["aa"].PackageFeeRate())
+	require.Equal(t, []Outpoint{{"p1", 0}, {"p2", 3}}, inputs["aa"])
+	require.Equal(t, int64(150), entries["bb"].VirtualSize())
+	require.Equal(t, 5.0, entries["bb"].FeeRate())
+	require.Equal(t, 5.0, entries["bb"].PackageFeeRate())
+	require.Empty(t, inputs["bb"])
+	require.NotContains(t, inputs, "gone")
+
+	_, err = client.Block(ctx, "unknown")
+	var rpcErr *RPCError
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, -5, rpcErr.Code)
+
+	unauthorized, err := NewBitcoinRPCClient(node.URL)
+	require.NoError(t, err)
+	_, err = unauthorized.MempoolInfo(ctx)
+	require.ErrorContains(t, err, "authentication failed")
+}
@@ -0,0 +1,440 @@
+package bitcoin
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/technicallyty/xray/chain"
+)
+
+const (
+	// verboseMempoolLimit is the largest mempool listed with getrawmempool true. Beyond it only txids are listed
+	// and entries are looked up for new txs, since the verbose listing of a full mempool runs into hundreds of MB.
+	verboseMempoolLimit = 5000
+	// maxEntryLookups bounds the getmempoolentry and getrawtransaction lookups per poll.
+	maxEntryLookups = 200
+	// entryRefresh is how old a looked up entry may get before it is looked up again, as its ancestors and
+	// descendants change while it is pending.
+	entryRefresh = time.Minute
+	// lineWidth is the width available to a row inside a box.
+	lineWidth = 58
+	// maxBlockScan is the most blocks walked back from the tip per poll to find included txs.
+	maxBlockScan = 6
+	// includedRetention is the number of blocks included txids are kept for.
+	includedRetention = 20
+)
+
+type StatusType string
+
+const (
+	StatusTypeInMempool StatusType = "in-mempool"
+	StatusTypeConfirmed StatusType = "confirmed"
+	StatusTypeReplaced  StatusType = "replaced"
+	StatusTypeEvicted   StatusType = "evicted"
+)
+
+type Transaction struct {
+	TxID string
+	// Entry is nil until the tx was looked up, entryAt is when it was.
+	Entry   *MempoolEntry
+	entryAt time.Time
+	// Inputs are the outputs the tx spends, nil until looked up.
+	Inputs        []Outpoint
+	Status        StatusType
+	FirstSeen     time.Time
+	TimeCompleted time.Time
+	// Height is the block the tx was confirmed in.
+	Height int64
+	// ReplacedBy is the tx that spent the same outputs, set for replaced txs.
+	ReplacedBy *Transaction
+}
+
+type BitcoinModel struct {
+	client       *BitcoinRPCClient
+	transactions map[string]*Transaction // txid -> transaction
+	completed    []*Transaction
+	name         string
+	pollingRate  time.Duration
+
+	info MempoolInfo
+	// bestHash and height are the tip the blocks were scanned up to, included the txids found in them.
+	bestHash string
+	height   int64
+	included map[string]int64 // txid -> height
+}
+
+func NewBitcoinModel(client *BitcoinRPCClient, endpoint string, pollingRate time.Duration) *BitcoinModel {
+	if u, err := url.Parse(endpoint); err == nil {
+		// keep rpc passwords off the screen
+		endpoint = u.Redacted()
+	}
+	return &BitcoinModel{
+		client:       client,
+		transactions: make(map[string]*Transaction),
+		completed:    make([]*Transaction, 0),
+		included:     make(map[string]int64),
+		name:         fmt.Sprintf("Bitcoin - %s", endpoint),
+		pollingRate:  pollingRate,
+	}
+}
+
+var (
+	// Styles for bitcoin transactions
+	inMempoolStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("33"))              // bright blue
+	successStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))              // bright green
+	evictedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("208"))             // orange
+	fadedStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Faint(true) // dim gray and faded
+
+	boxStyle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder()).
+			Padding(0, 1).
+			Width(60)
+)
+
+// truncate shortens s to at most n terminal cells.
+func truncate(s string, n int) string {
+	return ansi.Truncate(s, n, "…")
+}
+
+func shortenHash(hash string) string {
+	if len(hash) <= 10 {
+		return hash
+	}
+	return hash[:6] + "..." + hash[len(hash)-4:]
+}
+
+// formatVBytes formats a size in vbytes.
+func formatVBytes(n int64) string {
+	if n >= 1000000 {
+		return fmt.Sprintf("%.1fMvB", float64(n)/1000000)
+	} else if n >= 1000 {
+		return fmt.Sprintf("%.1fKvB", float64(n)/1000)
+	}
+	return fmt.Sprintf("%dvB", n)
+}
+
+func getStatusPrefix(status StatusType) string {
+	switch status {
+	case StatusTypeConfirmed:
+		return "✓ "
+	case StatusTypeReplaced:
+		return "⇄ "
+	case StatusTypeEvicted:
+		return "⚠ "
+	default:
+		return ""
+	}
+}
+
+func (b *BitcoinModel) Start(ctx context.Context) {
+	go chain.Poll(ctx, b.pollingRate, func() {
+		info, err := b.client.MempoolInfo(ctx)
+		if err != nil {
+			return
+		}
+		current, err := b.pollMempool(ctx, info)
+		if err != nil {
+			return
+		}
+		// blocks are scanned after the mempool, so a tx that left it for a block is found in one
+		b.scanBlocks(ctx)
+
+		if removed := chain.Removed(b.transactions, current); len(removed) > 0 {
+			b.settle(removed, current, time.Now())
+			b.completed = chain.AppendCompleted(b.completed, removed...)
+		}
+
+		b.info = info
+		b.transactions = current
+	})
+}
+
+// pollMempool lists the mempool and looks up the entries and inputs of txs that don't have them yet, newest first.
+// Without the verbose listing, the entries looked up longest ago are refreshed with what lookups are left.
+func (b *BitcoinModel) pollMempool(ctx context.Context, info MempoolInfo) (map[string]*Transaction, error) {
+	var (
+		txids   []string
+		verbose map[string]*MempoolEntry
+		err     error
+	)
+	if info.Size <= verboseMempoolLimit {
+		verbose, err = b.client.RawMempool(ctx)
+		for txid := range verbose {
+			txids = append(txids, txid)
+		}
+	} else {
+		txids, err = b.client.MempoolTxIDs(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	current := make(map[string]*Transaction, len(txids))
+	var lookup, stale []*Transaction
+	for _, txid := range txids {
+		tx, ok := b.transactions[txid]
+		if !ok {
+			tx = &Transaction{TxID: txid, Status: StatusTypeInMempool, FirstSeen: now}
+		}
+		if entry, ok := verbose[txid]; ok {
+			tx.Entry, tx.entryAt = entry, now
+		}
+		if tx.Entry == nil || tx.Inputs == nil {
+			lookup = append(lookup, tx)
+		} else if now.Sub(tx.entryAt) >= entryRefresh {
+			stale = append(stale, tx)
+		}
+		current[txid] = tx
+	}
+
+	// new txs first, they are the ones that may have replaced a tx that just left
+	slices.SortFunc(lookup, func(a, c *Transaction) int {
+		return c.FirstSeen.Compare(a.FirstSeen)
+	})
+	slices.SortFunc(stale, func(a, c *Transaction) int {
+		return a.entryAt.Compare(c.entryAt)
+	})
+	lookup = append(lookup, stale...)
+	lookup = lookup[:min(len(lookup), maxEntryLookups)]
+	lookupIDs := make([]string, len(lookup))
+	for i, tx := range lookup {
+		lookupIDs[i] = tx.TxID
+	}
+	entries, inputs, err := b.client.MempoolEntries(ctx, lookupIDs)
+	if err != nil {
+		// entries are retried next poll
+		return current, nil
+	}
+	for _, tx := range lookup {
+		if entry, ok := entries[tx.TxID]; ok {
+			tx.Entry, tx.entryAt = entry, now
+		}
+		if vin, ok := inputs[tx.TxID]; ok {
+			tx.Inputs = append([]Outpoint{}, vin...)
+		}
+	}
+	return current, nil
+}
+
+// scanBlocks records the txids of the blocks produced since the last scan, walking back from the tip.
+// Blocks orphaned by a reorg stay recorded until they are pruned.
+func (b *BitcoinModel) scanBlocks(ctx context.Context) {
+	best, err := b.client.BestBlockHash(ctx)
+	if err != nil || best == b.bestHash {
+		return
+	}
+	hash := best
+	var tip int64
+	for i := 0; i < maxBlockScan && hash != "" && hash != b.bestHash; i++ {
+		block, err := b.client.Block(ctx, hash)
+		if err != nil {
+			return
+		}
+		if i == 0 {
+			tip = block.Height
+		}
+		for _, txid := range block.Tx {
+			b.included[txid] = block.Height
+		}
+		hash = block.PreviousBlockHash
+	}
+	b.bestHash, b.height = best, tip
+	for txid, height := range b.included {
+		if height <= tip-includedRetention {
+			delete(b.included, txid)
+		}
+	}
+}
+
+// settle sets the status of txs that left the mempool: confirmed if a scanned block included them, replaced if a
+// tx still in the mempool spends one of the same outputs, and evicted otherwise. Evicted txs expired, were pushed out
+// by the mempool size limit, or conflicted with a block.
+func (b *BitcoinModel) settle(removed []*Transaction, current map[string]*Transaction, now time.Time) {
+	spenders := make(map[Outpoint]*Transaction)
+	for _, tx := range current {
+		for _, in := range tx.Inputs {
+			spenders[in] = tx
+		}
+	}
+	for _, tx := range removed {
+		tx.TimeCompleted = now
+		if height, ok := b.included[tx.TxID]; ok {
+			tx.Status = StatusTypeConfirmed
+			tx.Height = height
+			continue
+		}
+		tx.Status = StatusTypeEvicted
+		for _, in := range tx.Inputs {
+			if spender, ok := spenders[in]; ok {
+				tx.Status = StatusTypeReplaced
+				tx.ReplacedBy = spender
+				break
+			}
+		}
+	}
+}
+
+// replaceable reports whether the node would accept a replacement of the tx.
+func (b *BitcoinModel) replaceable(tx *Transaction) bool {
+	return b.info.FullRBF || tx.Entry != nil && tx.Entry.Replaceable
+}
+
+// mempoolLine renders a pending tx: its own fee rate, its size, and its ancestor package when it has one.
+// With full-RBF every tx is marked rbf.
+func (b *BitcoinModel) mempoolLine(tx *Transaction) string {
+	e := tx.Entry
+	line := fmt.Sprintf("%s | %.1f sat/vB | %s", shortenHash(tx.TxID), e.FeeRate(), formatVBytes(e.VirtualSize()))
+	if e.AncestorCount > 1 || e.DescendantCount > 1 {
+		line += fmt.Sprintf(" | pkg %d↑%d↓ %.1f", e.AncestorCount-1, e.DescendantCount-1, e.PackageFeeRate())
+	}
+	if b.replaceable(tx) {
+		line += " | rbf"
+	}
+	return truncate(line, lineWidth)
+}
+
+// packageLine renders a tx with unconfirmed ancestors or descendants. A child whose own fee rate is above the rate of
+// its package pays for its parents (CPFP).
+func packageLine(tx *Transaction) (string, bool) {
+	e := tx.Entry
+	cpfp := e.AncestorCount > 1 && e.FeeRate() > e.PackageFeeRate()
+	line := fmt.Sprintf("%s | %d↑%d↓ | pkg %.1f own %.1f sat/vB",
+		shortenHash(tx.TxID), e.AncestorCount-1, e.DescendantCount-1, e.PackageFeeRate(), e.FeeRate())
+	if cpfp {
+		line += " | cpfp"
+	}
+	return truncate(line, lineWidth), cpfp
+}
+
+// completedLine renders a tx that left the mempool, truncated to the box width.
+func completedLine(tx *Transaction) string {
+	return truncate(completedText(tx), lineWidth)
+}
+
+func completedText(tx *Transaction) string {
+	var rate string
+	if tx.Entry != nil {
+		rate = fmt.Sprintf("%.1f sat/vB", tx.Entry.FeeRate())
+	}
+	prefix := getStatusPrefix(tx.Status) + shortenHash(tx.TxID)
+	switch tx.Status {
+	case StatusTypeConfirmed:
+		return fmt.Sprintf("%s | H%d | %s | waited %s", prefix, tx.Height, rate, tx.TimeCompleted.Sub(tx.FirstSeen).Round(time.Second))
+	case StatusTypeReplaced:
+		line := fmt.Sprintf("%s | by %s", prefix, shortenHash(tx.ReplacedBy.TxID))
+		if tx.Entry != nil && tx.ReplacedBy.Entry != nil {
+			line += fmt.Sprintf(" | %.1f→%.1f sat/vB", tx.Entry.FeeRate(), tx.ReplacedBy.Entry.FeeRate())
+		}
+		return line
+	default:
+		return fmt.Sprintf("%s | dropped | %s", prefix, rate)
+	}
+}
+
+func (b *BitcoinModel) Displays() []string {
+	var displays []string
+	const maxTxsPerBox = 8 // leave 2 lines for header and separator
+
+	var txs []*Transaction
+	for _, tx := range b.transactions {
+		if tx.Entry != nil {
+			txs = append(txs, tx)
+		}
+	}
+	// by package fee rate, which is the order miners select txs in
+	slices.SortFunc(txs, func(a, c *Transaction) int {
+		if order := cmp.Compare(c.Entry.PackageFeeRate(), a.Entry.PackageFeeRate()); order != 0 {
+			return order
+		}
+		return strings.Compare(a.TxID, c.TxID)
+	})
+
+	// MEMPOOL UI
+	{
+		info := b.info
+		// full-RBF shows as every row being marked rbf
+		header := fmt.Sprintf("Mempool (%d txs, %s, ≥%.2f sat/vB", info.Size, formatVBytes(info.Bytes), info.MempoolMinFee*1e5)
+		if len(txs) < info.Size {
+			header += fmt.Sprintf(", %d looked up", len(txs))
+		}
+		lines := []string{truncate(header+")", lineWidth), strings.Repeat("-", 50)}
+		for _, tx := range txs[:min(len(txs), maxTxsPerBox)] {
+			lines = append(lines, inMempoolStyle.Render(b.mempoolLine(tx)))
+		}
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+		displays = append(displays, boxStyle.Render(strings.Join(lines, "\n")))
+	}
+
+	// PACKAGES UI
+	{
+		var packages []*Transaction
+		for _, tx := range txs {
+			if tx.Entry.AncestorCount > 1 || tx.Entry.DescendantCount > 1 {
+				packages = append(packages, tx)
+			}
+		}
+		lines := []string{fmt.Sprintf("Packages (%d txs with unconfirmed relatives)", len(packages)), strings.Repeat("-", 50)}
+		for _, tx := range packages[:min(len(packages), maxTxsPerBox)] {
+			line, cpfp := packageLine(tx)
+			if cpfp {
+				line = evictedStyle.Render(line)
+			} else {
+				line = inMempoolStyle.Render(line)
+			}
+			lines = append(lines, line)
+		}
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+		displays = append(displays, boxStyle.Render(strings.Join(lines, "\n")))
+	}
+
+	// COMPLETED UI
+	{
+		completed := make([]*Transaction, len(b.completed))
+		copy(completed, b.completed)
+		slices.Reverse(completed)
+
+		var replaced int
+		for _, tx := range completed {
+			if tx.Status == StatusTypeReplaced {
+				replaced++
+			}
+		}
+		lines := []string{fmt.Sprintf("Completed (tip %d, %d replaced)", b.height, replaced), strings.Repeat("-", 50)}
+		for _, tx := range completed[:min(len(completed), maxTxsPerBox)] {
+			line := completedLine(tx)
+			switch tx.Status {
+			case StatusTypeConfirmed:
+				line = successStyle.Render(line)
+			case StatusTypeReplaced:
+				line = evictedStyle.Render(line)
+			default:
+				line = fadedStyle.Render(line)
+			}
+			lines = append(lines, line)
+		}
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+		displays = append(displays, boxStyle.Render(strings.Join(lines, "\n")))
+	}
+
+	return displays
+}
+
+func (b *BitcoinModel) Name() string {
+	return b.name
+}
+
+var _ chain.MempoolXray = &BitcoinModel{}
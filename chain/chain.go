@@ -11,3 +11,9 @@ type MempoolXray interface {
 	// Name is the name of the service.
 	Name() string
 }
+
+// Filterable is implemented by services that can narrow their displays to a search typed in the UI.
+type Filterable interface {
+	// SetFilter sets the search, an empty query clears it.
+	SetFilter(query string)
+}
@@ -0,0 +1,218 @@
+package cosmos
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// Exec is an authz MsgExec: the grantee signs the tx, but the messages it executes act for the granters.
+type Exec struct {
+	Grantee string
+	Msgs    []DecodedMsg
+	// Granters are the signers of the executed messages, the accounts actually acting.
+	Granters []string
+}
+
+// Execs unwraps the MsgExec messages of a tx.
+func (c *Codec) Execs(msgs []DecodedMsg) []Exec {
+	var execs []Exec
+	for _, msg := range msgs {
+		exec, ok := msg.Msg.(*authz.MsgExec)
+		if !ok {
+			continue
+		}
+		e := Exec{Grantee: exec.Grantee}
+		for _, any := range exec.Msgs {
+			inner := DecodedMsg{TypeURL: any.TypeUrl}
+			// the registry unpacked the executed messages along with the MsgExec
+			if m, ok := any.GetCachedValue().(sdk.Msg); ok {
+				inner.Msg = m
+			}
+			e.Msgs = append(e.Msgs, inner)
+			for _, signer := range c.MsgSigners(inner) {
+				if !slices.Contains(e.Granters, signer) {
+					e.Granters = append(e.Granters, signer)
+				}
+			}
+		}
+		execs = append(execs, e)
+	}
+	return execs
+}
+
+func isMsgExec(typeURL string) bool {
+	return typeURL == sdk.MsgTypeURL(&authz.MsgExec{})
+}
+
+// formatExecs summarizes the executed messages for the message type column, e.g. "exec MsgSend +1".
+func formatExecs(execs []Exec) string {
+	var types []string
+	for _, exec := range execs {
+		for _, msg := range exec.Msgs {
+			typeURL := msg.TypeURL
+			if idx := strings.LastIndex(typeURL, "."); idx != -1 {
+				typeURL = typeURL[idx+1:]
+			}
+			types = append(types, typeURL)
+		}
+	}
+	switch len(types) {
+	case 0:
+		return "exec"
+	case 1:
+		return "exec " + types[0]
+	}
+	return fmt.Sprintf("exec %s +%d", types[0], len(types)-1)
+}
+
+// feeGranter returns the account paying the fee of a tx through a feegrant allowance, if any.
+func feeGranter(tx *tx.Tx) string {
+	if tx == nil || tx.AuthInfo == nil || tx.AuthInfo.Fee == nil {
+		return ""
+	}
+	return tx.AuthInfo.Fee.Granter
+}
+
+// feePayer returns the account set to pay the fee of a tx instead of its first signer, if any.
+func feePayer(tx *tx.Tx) string {
+	if tx == nil || tx.AuthInfo == nil || tx.AuthInfo.Fee == nil {
+		return ""
+	}
+	return tx.AuthInfo.Fee.Payer
+}
+
+// feeMarker flags the fee column of txs whose fee is not paid by their first signer: * for a fee granter and
+// + for a fee payer, such as a relayer.
+func feeMarker(tx *tx.Tx) string {
+	switch {
+	case feeGranter(tx) != "":
+		return "*"
+	case feePayer(tx) != "":
+		return "+"
+	}
+	return ""
+}
+
+// execDetailLines renders the MsgExecs of a tx for the detail box, with the JSON of the executed messages.
+func (c *Codec) execDetailLines(execs []Exec, width int) []string {
+	var lines []string
+	for _, exec := range execs {
+		header := "MsgExec by grantee " + exec.Grantee
+		lines = append(lines, inMempoolStyleCosmos.Render(truncate(header, width)))
+		for _, granter := range exec.Granters {
+			lines = append(lines, truncate("  for granter "+granter, width))
+		}
+		for _, msg := range exec.Msgs {
+			lines = append(lines, inMempoolStyleCosmos.Render("  "+msg.TypeURL))
+			lines = append(lines, wrapLines(c.MsgJSON(msg), width)...)
+		}
+	}
+	return lines
+}
+
+// grantTally is the fees a granter paid for its grantees over the session.
+type grantTally struct {
+	txs      int
+	fees     sdk.Coins
+	grantees map[string]bool
+}
+
+// GranterSummary is the spend of a fee granter.
+type GranterSummary struct {
+	Granter string
+	// Txs and Fees count the included txs the granter paid for, Pending the txs still in the mempool.
+	Txs      int
+	Fees     sdk.Coins
+	Grantees int
+	Pending  int
+}
+
+// tallyGrants adds the fees of included txs paid through a feegrant to their granter. Txs are counted once, whether
+// they succeeded or failed, since fees are charged either way.
+func (c *CosmosModel) tallyGrants(txs []*CosmosTransaction) {
+	for _, tx := range txs {
+		granter := feeGranter(tx.Tx)
+		if granter == "" || tx.grantTallied || (tx.Status != StatusTypeSuccess && tx.Status != StatusTypeFailed) {
+			continue
+		}
+		tx.grantTallied = true
+		tally, ok := c.grants[granter]
+		if !ok {
+			tally = &grantTally{grantees: make(map[string]bool)}
+			c.grants[granter] = tally
+		}
+		tally.txs++
+		tally.fees = tally.fees.Add(txFee(tx.Tx)...)
+		if grantee := feeGrantee(tx); grantee != "" {
+			tally.grantees[grantee] = true
+		}
+	}
+}
+
+// feeGrantee returns the account a fee grant is used by: the fee payer, or the first signer when unset.
+func feeGrantee(tx *CosmosTransaction) string {
+	if payer := feePayer(tx.Tx); payer != "" {
+		return payer
+	}
+	if len(tx.Signers) > 0 {
+		return tx.Signers[0]
+	}
+	return ""
+}
+
+// summarizeGrants combines the session tallies with the pending txs of each granter, most txs first.
+func summarizeGrants(grants map[string]*grantTally, txs map[string]*CosmosTransaction) []GranterSummary {
+	summaries := make(map[string]*GranterSummary)
+	for granter, tally := range grants {
+		summaries[granter] = &GranterSummary{Granter: granter, Txs: tally.txs, Fees: tally.fees, Grantees: len(tally.grantees)}
+	}
+	for _, tx := range txs {
+		granter := feeGranter(tx.Tx)
+		if granter == "" || tx.Status != StatusTypeInMempool {
+			continue
+		}
+		summary, ok := summaries[granter]
+		if !ok {
+			summary = &GranterSummary{Granter: granter}
+			summaries[granter] = summary
+		}
+		summary.Pending++
+	}
+
+	result := make([]GranterSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, *summary)
+	}
+	slices.SortFunc(result, func(a, b GranterSummary) int {
+		if order := cmp.Compare(b.Txs+b.Pending, a.Txs+a.Pending); order != 0 {
+			return order
+		}
+		return strings.Compare(a.Granter, b.Granter)
+	})
+	return result
+}
+
+// formatCoins formats the first coin, noting how many other denoms there are.
+func formatCoins(coins sdk.Coins) string {
+	if len(coins) == 0 {
+		return "0"
+	}
+	s := formatAmount(coins[0].Amount.BigInt()) + shortDenom(coins[0].Denom)
+	if len(coins) > 1 {
+		s += fmt.Sprintf("+%d", len(coins)-1)
+	}
+	return s
+}
+
+// granterLine renders a granter summary as a row of the fee grants box: fees paid for included txs, then grantees
+// and pending txs as 3g/1p.
+func granterLine(summary GranterSummary) string {
+	return truncate(fmt.Sprintf("%s | %d txs | %s | %dg/%dp",
+		shortenAddress(summary.Granter), summary.Txs, formatCoins(summary.Fees), summary.Grantees, summary.Pending), lineWidth)
+}
@@ -0,0 +1,97 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecs(t *testing.T) {
+	granter := sdk.MustBech32ifyAddressBytes("cosmos", []byte("granter_____________"))
+	grantee := sdk.MustBech32ifyAddressBytes("cosmos", []byte("grantee_____________"))
+	send := &banktypes.MsgSend{FromAddress: granter, ToAddress: grantee, Amount: sdk.NewCoins(sdk.NewInt64Coin("uatom", 5))}
+	delegate := &stakingtypes.MsgDelegate{DelegatorAddress: granter, ValidatorAddress: "cosmosvaloper1x", Amount: sdk.NewInt64Coin("uatom", 1)}
+	exec := authz.NewMsgExec(sdk.MustAccAddressFromBech32(grantee), []sdk.Msg{send, delegate})
+
+	decoded, _ := testTx(t, &exec)
+	c, err := NewCodec("")
+	require.NoError(t, err)
+	msgs := c.DecodeMsgs(decoded)
+	require.True(t, isMsgExec(msgs[0].TypeURL))
+
+	execs := c.Execs(msgs)
+	require.Len(t, execs, 1)
+	require.Equal(t, grantee, execs[0].Grantee)
+	require.Equal(t, []string{granter}, execs[0].Granters)
+	require.Len(t, execs[0].Msgs, 2)
+	require.Equal(t, send, execs[0].Msgs[0].Msg)
+	require.Equal(t, "exec MsgSend +1", formatExecs(execs))
+
+	index := newSearchIndex("", msgs, nil)
+	for _, exec := range execs {
+		index = index.with(exec.Msgs, exec.Granters)
+	}
+	require.True(t, parseFilter("type:msgdelegate signer:"+granter).matches(&CosmosTransaction{search: index}))
+
+	sendTx, _ := testTx(t, send)
+	require.Empty(t, c.Execs(c.DecodeMsgs(sendTx)))
+}
+
+func TestGrantTally(t *testing.T) {
+	c, err := NewCosmosModel(nil, "http://localhost:26657", 0, Options{})
+	require.NoError(t, err)
+	granted := func(hash, granter, payer string, status StatusType, amount int64) *CosmosTransaction {
+		return &CosmosTransaction{
+			Hash:    hash,
+			Status:  status,
+			Signers: []string{"cosmos1signer" + hash},
+			Tx: &tx.Tx{AuthInfo: &tx.AuthInfo{Fee: &tx.Fee{
+				Amount:  sdk.NewCoins(sdk.NewInt64Coin("uatom", amount)),
+				Granter: granter,
+				Payer:   payer,
+			}}},
+		}
+	}
+
+	a := granted("A", "cosmos1dao", "", StatusTypeSuccess, 1000)
+	b := granted("B", "cosmos1dao", "", StatusTypeFailed, 500)
+	evicted := granted("C", "cosmos1dao", "", StatusTypeEvicted, 700)
+	relayed := granted("D", "", "cosmos1relayer", StatusTypeSuccess, 100)
+	c.tallyGrants([]*CosmosTransaction{a, b, evicted, relayed})
+	// txs seen twice are counted once
+	c.tallyGrants([]*CosmosTransaction{a})
+
+	require.Equal(t, "*", feeMarker(a.Tx))
+	require.Equal(t, "+", feeMarker(relayed.Tx))
+	require.Equal(t, "", feeMarker(nil))
+
+	pending := map[string]*CosmosTransaction{
+		"E": granted("E", "cosmos1dao", "", StatusTypeInMempool, 10),
+		"F": granted("F", "cosmos1faucet", "", StatusTypeInMempool, 10),
+	}
+	summaries := summarizeGrants(c.grants, pending)
+	require.Len(t, summaries, 2)
+	require.Equal(t, GranterSummary{
+		Granter:  "cosmos1dao",
+		Txs:      2,
+		Fees:     sdk.NewCoins(sdk.NewInt64Coin("uatom", 1500)),
+		Grantees: 2,
+		Pending:  1,
+	}, summaries[0])
+	require.Equal(t, GranterSummary{Granter: "cosmos1faucet", Pending: 1}, summaries[1])
+	require.Equal(t, "cosmos1dao | 2 txs | 1.5Kuatom | 2g/1p", granterLine(summaries[0]))
+	busy := GranterSummary{
+		Granter:  "osmo1qxyzabcdefghijklmnopqrstuvwxyza1v7xu",
+		Txs:      12345,
+		Fees:     sdk.NewCoins(sdk.NewInt64Coin("ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", 123_456_789), sdk.NewInt64Coin("uosmo", 1)),
+		Grantees: 1234,
+		Pending:  567,
+	}
+	require.LessOrEqual(t, lipgloss.Width(granterLine(busy)), lineWidth)
+}
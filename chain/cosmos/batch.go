@@ -0,0 +1,251 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+)
+
+const (
+	// defaultTxBatchSize is the number of /tx queries sent per batch until the node reports a lower limit.
+	defaultTxBatchSize = 100
+	// txStatusConcurrency bounds the number of batches in flight.
+	txStatusConcurrency = 4
+	// txStatusTimeout is the deadline of a single batch.
+	txStatusTimeout = 5 * time.Second
+	// txStatusRetries is the number of times a hash is retried after a transient error.
+	txStatusRetries = 3
+)
+
+// batchLimitRegex extracts the limit from CometBFT's "batch request exceeds maximum (10) allowed number of requests".
+var batchLimitRegex = regexp.MustCompile(`exceeds maximum \((\d+)\)`)
+
+// txStatusOutcome is what a single /tx query in a batch resolved to.
+type txStatusOutcome int
+
+const (
+	txStatusRetry txStatusOutcome = iota
+	txStatusFound
+	txStatusNotFound
+)
+
+// batchTooLargeError is returned when the node rejects a batch larger than its max_request_batch_size.
+type batchTooLargeError struct {
+	limit int
+}
+
+func (e batchTooLargeError) Error() string {
+	return fmt.Sprintf("batch exceeds the node's limit of %d requests", e.limit)
+}
+
+// batchClient sends JSON-RPC batches to CometBFT. CometBFT's own batch client fails the whole batch when a single
+// request errors, which every /tx query for a tx that was never included does, so the responses are decoded here one by one.
+type batchClient struct {
+	address  string
+	username string
+	password string
+	client   *http.Client
+
+	mu        sync.Mutex
+	batchSize int
+}
+
+func newBatchClient(endpoint string) (*batchClient, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "tcp" {
+		u.Scheme = "http"
+	}
+	b := &batchClient{client: &http.Client{}, batchSize: defaultTxBatchSize}
+	if u.User != nil {
+		b.username = u.User.Username()
+		b.password, _ = u.User.Password()
+		u.User = nil
+	}
+	b.address = u.String()
+	return b, nil
+}
+
+func (b *batchClient) size() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.batchSize
+}
+
+func (b *batchClient) setSize(size int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if size > 0 && size < b.batchSize {
+		b.batchSize = size
+	}
+}
+
+// txStatuses queries /tx for every hash in a single JSON-RPC batch.
+func (b *batchClient) txStatuses(ctx context.Context, hashes []string) ([]*TxResult, []txStatusOutcome, error) {
+	requests := make([]rpctypes.RPCRequest, len(hashes))
+	for i, hash := range hashes {
+		hashBytes, err := hex.DecodeString(hash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode hex hash: %w", err)
+		}
+		requests[i], err = rpctypes.MapToRequest(rpctypes.JSONRPCIntID(i), "tx", map[string]interface{}{
+			"hash":  hashBytes,
+			"prove": false,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	body, err := json.Marshal(requests)
+	if err != nil {
+		return nil, nil, err
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, b.address, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	if b.username != "" || b.password != "" {
+		httpRequest.SetBasicAuth(b.username, b.password)
+	}
+
+	httpResponse, err := b.client.Do(httpRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var responses []rpctypes.RPCResponse
+	if err := json.Unmarshal(responseBytes, &responses); err != nil {
+		// the whole batch was rejected with a single error response
+		var single rpctypes.RPCResponse
+		if json.Unmarshal(responseBytes, &single) == nil && single.Error != nil {
+			if match := batchLimitRegex.FindStringSubmatch(single.Error.Data); match != nil {
+				limit, _ := strconv.Atoi(match[1])
+				return nil, nil, batchTooLargeError{limit: limit}
+			}
+			return nil, nil, single.Error
+		}
+		return nil, nil, fmt.Errorf("failed to decode batch response: %w", err)
+	}
+
+	results := make([]*TxResult, len(hashes))
+	outcomes := make([]txStatusOutcome, len(hashes))
+	for _, response := range responses {
+		id, ok := response.ID.(rpctypes.JSONRPCIntID)
+		if !ok || int(id) < 0 || int(id) >= len(hashes) {
+			continue
+		}
+		if response.Error != nil {
+			if strings.Contains(response.Error.Data, "not found") {
+				outcomes[id] = txStatusNotFound
+			}
+			continue
+		}
+		var result coretypes.ResultTx
+		if err := cmtjson.Unmarshal(response.Result, &result); err != nil {
+			continue
+		}
+		results[id] = &TxResult{TxResult: result.TxResult, Height: result.Height, Index: result.Index}
+		outcomes[id] = txStatusFound
+	}
+	return results, outcomes, nil
+}
+
+// BatchTxStatus queries the status of many txs with batched /tx calls, running a bounded number of batches concurrently.
+// Hashes that hit a transient error are retried on their own, up to txStatusRetries times.
+// The result is nil for txs that were not found or could not be queried.
+func (c *CosmosRPCClient) BatchTxStatus(ctx context.Context, txHashes []string) ([]*TxResult, error) {
+	results := make([]*TxResult, len(txHashes))
+
+	pending := make([]int, len(txHashes))
+	for i := range pending {
+		pending[i] = i
+	}
+
+	for attempt := 0; attempt <= txStatusRetries && len(pending) > 0; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return results, ctx.Err()
+			case <-time.After(time.Duration(100<<attempt) * time.Millisecond):
+			}
+		}
+		pending = c.txStatusRound(ctx, txHashes, pending, results)
+	}
+	return results, nil
+}
+
+// txStatusRound queries the given indices of txHashes once, filling results, and returns the indices to retry.
+func (c *CosmosRPCClient) txStatusRound(ctx context.Context, txHashes []string, indices []int, results []*TxResult) []int {
+	var (
+		mu    sync.Mutex
+		retry []int
+		wg    sync.WaitGroup
+		sem   = make(chan struct{}, txStatusConcurrency)
+	)
+
+	size := c.batch.size()
+	for start := 0; start < len(indices); start += size {
+		chunk := indices[start:min(start+size, len(indices))]
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			hashes := make([]string, len(chunk))
+			for i, idx := range chunk {
+				hashes[i] = txHashes[idx]
+			}
+
+			batchCtx, cancel := context.WithTimeout(ctx, txStatusTimeout)
+			defer cancel()
+			chunkResults, outcomes, err := c.batch.txStatuses(batchCtx, hashes)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if tooLarge, ok := err.(batchTooLargeError); ok {
+					c.batch.setSize(tooLarge.limit)
+				}
+				retry = append(retry, chunk...)
+				return
+			}
+			for i, idx := range chunk {
+				switch outcomes[i] {
+				case txStatusFound:
+					results[idx] = chunkResults[i]
+				case txStatusRetry:
+					retry = append(retry, idx)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return retry
+}
@@ -0,0 +1,90 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/stretchr/testify/require"
+)
+
+// batchNode emulates CometBFT's /tx batch handling with a max_request_batch_size of maxBatch.
+// Hashes starting with "AA" are included at height 7, all others are not found.
+func batchNode(t *testing.T, maxBatch int, requests *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var batch []rpctypes.RPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+
+		if len(batch) > maxBatch {
+			err := fmt.Errorf("batch request exceeds maximum (%d) allowed number of requests", maxBatch)
+			_ = json.NewEncoder(w).Encode(rpctypes.RPCInvalidRequestError(nil, err))
+			return
+		}
+
+		responses := make([]rpctypes.RPCResponse, len(batch))
+		for i, req := range batch {
+			var params struct {
+				Hash []byte `json:"hash"`
+			}
+			require.NoError(t, cmtjson.Unmarshal(req.Params, &params))
+			hash := fmt.Sprintf("%X", params.Hash)
+			if !strings.HasPrefix(hash, "AA") {
+				responses[i] = rpctypes.RPCInternalError(req.ID, fmt.Errorf("tx (%s) not found", hash))
+				continue
+			}
+			responses[i] = rpctypes.NewRPCSuccessResponse(req.ID, &coretypes.ResultTx{
+				Height:   7,
+				Index:    uint32(i),
+				TxResult: abci.ExecTxResult{GasUsed: 100},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(responses)
+	}))
+}
+
+func TestBatchTxStatus(t *testing.T) {
+	var requests atomic.Int32
+	node := batchNode(t, 10, &requests)
+	defer node.Close()
+
+	client, err := NewCosmosRPCClient(node.URL)
+	require.NoError(t, err)
+
+	hashes := make([]string, 35)
+	for i := range hashes {
+		prefix := "BB"
+		if i%2 == 0 {
+			prefix = "AA"
+		}
+		hashes[i] = fmt.Sprintf("%s%062X", prefix, i)
+	}
+
+	results, err := client.BatchTxStatus(t.Context(), hashes)
+	require.NoError(t, err)
+	require.Len(t, results, len(hashes))
+	for i, result := range results {
+		if i%2 == 0 {
+			require.NotNil(t, result, "hash %d", i)
+			require.Equal(t, int64(7), result.Height)
+		} else {
+			require.Nil(t, result, "hash %d", i)
+		}
+	}
+	// one rejected batch, then 4 batches at the node's limit
+	require.Equal(t, int32(5), requests.Load())
+	require.Equal(t, 10, client.batch.size())
+
+	// the limit is remembered
+	_, err = client.BatchTxStatus(t.Context(), hashes)
+	require.NoError(t, err)
+	require.Equal(t, int32(9), requests.Load())
+}
@@ -0,0 +1,250 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+const (
+	// DefaultLane takes the txs no lane matches, like the default lane of the Block SDK.
+	DefaultLane = "default"
+	// recentAuctions is the number of auction results kept.
+	recentAuctions = 20
+)
+
+// Lane matches txs to a lane of a Block SDK mempool. A tx matches when every message has one of MsgTypes, if set,
+// and one of its signers is in Signers, if set. A MsgType ending in * matches by prefix, e.g. "/cosmos.staking.*".
+type Lane struct {
+	Name     string
+	MsgTypes []string
+	Signers  []string
+}
+
+// DefaultLanes are used when none are configured: the MEV lane, which takes auction bids.
+var DefaultLanes = []Lane{{Name: "mev", MsgTypes: []string{msgAuctionBidTypeURL, pobMsgAuctionBidTypeURL}}}
+
+func (l Lane) matches(msgs []DecodedMsg, signers []string) bool {
+	if len(l.MsgTypes) == 0 && len(l.Signers) == 0 {
+		return false
+	}
+	if len(l.MsgTypes) > 0 {
+		if len(msgs) == 0 {
+			return false
+		}
+		for _, msg := range msgs {
+			if !slices.ContainsFunc(l.MsgTypes, func(pattern string) bool { return matchType(pattern, msg.TypeURL) }) {
+				return false
+			}
+		}
+	}
+	if len(l.Signers) > 0 && !slices.ContainsFunc(signers, func(signer string) bool { return slices.Contains(l.Signers, signer) }) {
+		return false
+	}
+	return true
+}
+
+func matchType(pattern, typeURL string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(typeURL, prefix)
+	}
+	return pattern == typeURL
+}
+
+// laneOf returns the first lane matching a tx, in priority order, or DefaultLane.
+func laneOf(lanes []Lane, msgs []DecodedMsg, signers []string) string {
+	for _, lane := range lanes {
+		if lane.matches(msgs, signers) {
+			return lane.Name
+		}
+	}
+	return DefaultLane
+}
+
+const (
+	msgAuctionBidTypeURL = "/sdk.auction.v1.MsgAuctionBid"
+	// pobMsgAuctionBidTypeURL is the bid of the protocol-owned builder, which the Block SDK replaced.
+	pobMsgAuctionBidTypeURL = "/pob.builder.v1.MsgAuctionBid"
+)
+
+// AuctionBid is a decoded MsgAuctionBid.
+type AuctionBid struct {
+	Bidder string
+	Denom  string
+	Amount *big.Int
+	// Bundle are the hashes of the bundled txs, in the order the bid asks them to be included in.
+	Bundle []string
+}
+
+func isMsgAuctionBid(typeURL string) bool {
+	return typeURL == msgAuctionBidTypeURL || typeURL == pobMsgAuctionBidTypeURL
+}
+
+// unwrapAuctionBid returns the MsgAuctionBid of a tx, or nil if it has none that decodes.
+// Like MsgPayForBlobs it is decoded by hand, to not depend on the Block SDK.
+func unwrapAuctionBid(tx *tx.Tx) *AuctionBid {
+	if tx == nil || tx.Body == nil {
+		return nil
+	}
+	for _, msg := range tx.Body.Messages {
+		if !isMsgAuctionBid(msg.TypeUrl) {
+			continue
+		}
+		if bid, err := decodeMsgAuctionBid(msg.Value); err == nil {
+			return bid
+		}
+	}
+	return nil
+}
+
+// decodeMsgAuctionBid decodes MsgAuctionBid{bidder = 1, bid = 2, transactions = 3}, where bid is a Coin{denom = 1, amount = 2}.
+func decodeMsgAuctionBid(b []byte) (*AuctionBid, error) {
+	m, err := parseProto(b)
+	if err != nil {
+		return nil, err
+	}
+	coin, err := parseProto(m.field(2))
+	if err != nil {
+		return nil, fmt.Errorf("bid: %w", err)
+	}
+	amount, err := coin.bigInt(2)
+	if err != nil {
+		return nil, fmt.Errorf("bid: %w", err)
+	}
+	bid := &AuctionBid{Bidder: m.str(1), Denom: coin.str(1), Amount: amount}
+	for _, bundled := range m.bytes[3] {
+		bid.Bundle = append(bid.Bundle, TxHash(bundled))
+	}
+	return bid, nil
+}
+
+// formatBid summarizes a bid for the message type column, e.g. "bid 1.2Kuatom/3".
+func formatBid(bid *AuctionBid) string {
+	return fmt.Sprintf("bid %s%s/%d", formatAmount(bid.Amount), shortDenom(bid.Denom), len(bid.Bundle))
+}
+
+// bidDetailLines renders a MsgAuctionBid for the detail box.
+func bidDetailLines(bid *AuctionBid) []string {
+	lines := []string{
+		inMempoolStyleCosmos.Render("MsgAuctionBid"),
+		"bidder " + bid.Bidder,
+		fmt.Sprintf("bid %s%s for a bundle of %d txs", bid.Amount, bid.Denom, len(bid.Bundle)),
+	}
+	for i, hash := range bid.Bundle {
+		lines = append(lines, fmt.Sprintf("  %d. %s", i+1, truncateHash(hash)))
+	}
+	return lines
+}
+
+// AuctionResult is the auction won in a block. The Block SDK puts the winning bid first, followed by its bundle.
+type AuctionResult struct {
+	Height  int64
+	BidHash string
+	Bid     *AuctionBid
+	// Bundled is set when the bundle follows the bid in order, as the auction guarantees.
+	Bundled bool
+}
+
+// blockAuction returns the auction won in a block, if its first tx is a bid.
+func blockAuction(block *cmttypes.Block) (AuctionResult, bool) {
+	if len(block.Txs) == 0 {
+		return AuctionResult{}, false
+	}
+	decoded, err := decodeTransaction(block.Txs[0])
+	if err != nil {
+		return AuctionResult{}, false
+	}
+	bid := unwrapAuctionBid(decoded)
+	if bid == nil {
+		return AuctionResult{}, false
+	}
+	result := AuctionResult{Height: block.Height, BidHash: TxHash(block.Txs[0]), Bid: bid}
+	hashes := blockTxHashes(block)[1:]
+	result.Bundled = len(hashes) >= len(bid.Bundle) && slices.Equal(hashes[:len(bid.Bundle)], bid.Bundle)
+	return result, true
+}
+
+// recordAuction appends the auction won in a block to the recent auctions, in height order.
+// Must be called with c.mu held.
+func (c *CosmosModel) recordAuction(result AuctionResult) {
+	if n := len(c.recentAuctions); n > 0 && result.Height <= c.recentAuctions[n-1].Height {
+		return
+	}
+	c.recentAuctions = append(c.recentAuctions, result)
+	if len(c.recentAuctions) > recentAuctions {
+		c.recentAuctions = c.recentAuctions[len(c.recentAuctions)-recentAuctions:]
+	}
+}
+
+// observeAuction looks for an auction won in the block at height when block events are not delivering blocks.
+// Blocks are only queried once a bid was seen, so chains without an auction cost nothing.
+func (c *CosmosModel) observeAuction(ctx context.Context, height int64) {
+	if height <= c.auctionHeight || c.bidsSeen == 0 || c.eventsLive() {
+		return
+	}
+	block, err := c.client.Block(ctx, height)
+	if err != nil {
+		return
+	}
+	c.auctionHeight = height
+	if result, ok := blockAuction(block); ok {
+		c.mu.Lock()
+		c.recordAuction(result)
+		c.mu.Unlock()
+	}
+}
+
+// LaneSummary is the pending txs of a lane.
+type LaneSummary struct {
+	Name  string
+	Txs   int
+	Bytes int64
+	Gas   uint64
+}
+
+// summarizeLanes totals the pending txs by lane, in priority order with the default lane last.
+func summarizeLanes(lanes []Lane, txs map[string]*CosmosTransaction) []LaneSummary {
+	summaries := make([]LaneSummary, 0, len(lanes)+1)
+	index := make(map[string]int)
+	for _, lane := range append(slices.Clone(lanes), Lane{Name: DefaultLane}) {
+		if _, ok := index[lane.Name]; !ok {
+			index[lane.Name] = len(summaries)
+			summaries = append(summaries, LaneSummary{Name: lane.Name})
+		}
+	}
+	for _, tx := range txs {
+		if tx.Status != StatusTypeInMempool {
+			continue
+		}
+		summary := &summaries[index[tx.Lane]]
+		summary.Txs++
+		summary.Bytes += int64(len(tx.Raw))
+		summary.Gas += gasLimit(tx.Tx)
+	}
+	return summaries
+}
+
+// laneLine renders a lane summary as a row of the lanes box.
+func laneLine(summary LaneSummary) string {
+	return fmt.Sprintf("%s | %d txs | %s | %s gas", truncate(summary.Name, 16), summary.Txs, formatBytes(int(summary.Bytes)), formatGas(summary.Gas))
+}
+
+// bidLine renders a pending bid as a row of the lanes box.
+func bidLine(tx *CosmosTransaction) string {
+	return truncate(fmt.Sprintf("%s | %s | %s", truncateHash(tx.Hash), shortenAddress(tx.Bid.Bidder), formatBid(tx.Bid)), lineWidth)
+}
+
+// auctionLine renders an auction result as a row of the auctions box, marked ✓ when the bundle followed the bid in
+// order and ⚠ when it was reordered.
+func auctionLine(result AuctionResult) string {
+	bundled := "✓"
+	if !result.Bundled {
+		bundled = "⚠"
+	}
+	return truncate(fmt.Sprintf("%s H%d | %s | %s", bundled, result.Height, shortenAddress(result.Bid.Bidder), formatBid(result.Bid)), lineWidth)
+}
@@ -0,0 +1,114 @@
+package cosmos
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	cmttypes "github.com/cometbft/cometbft/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/require"
+)
+
+// sdkTx encodes a tx with a single message.
+func sdkTx(t *testing.T, typeURL string, value []byte) []byte {
+	bz, err := (&tx.Tx{Body: &tx.TxBody{Messages: []*codectypes.Any{{TypeUrl: typeURL, Value: value}}}}).Marshal()
+	require.NoError(t, err)
+	return bz
+}
+
+// auctionBid encodes a MsgAuctionBid for a bundle of txs.
+func auctionBid(bidder, denom, amount string, bundle ...[]byte) []byte {
+	var msg []byte
+	msg = appendString(msg, 1, bidder)
+	msg = appendBytes(msg, 2, appendString(appendString(nil, 1, denom), 2, amount))
+	for _, bundled := range bundle {
+		msg = appendBytes(msg, 3, bundled)
+	}
+	return msg
+}
+
+func TestLaneOf(t *testing.T) {
+	lanes := []Lane{
+		{Name: "mev", MsgTypes: []string{msgAuctionBidTypeURL}},
+		{Name: "free", MsgTypes: []string{"/cosmos.staking.*"}},
+		{Name: "oracle", Signers: []string{"cosmos1oracle"}},
+	}
+	msgs := func(typeURLs ...string) []DecodedMsg {
+		var decoded []DecodedMsg
+		for _, typeURL := range typeURLs {
+			decoded = append(decoded, DecodedMsg{TypeURL: typeURL})
+		}
+		return decoded
+	}
+
+	require.Equal(t, "mev", laneOf(lanes, msgs(msgAuctionBidTypeURL), nil))
+	require.Equal(t, "free", laneOf(lanes, msgs("/cosmos.staking.v1beta1.MsgDelegate", "/cosmos.staking.v1beta1.MsgUndelegate"), nil))
+	// every message has to match the lane
+	require.Equal(t, DefaultLane, laneOf(lanes, msgs("/cosmos.staking.v1beta1.MsgDelegate", "/cosmos.bank.v1beta1.MsgSend"), nil))
+	require.Equal(t, "oracle", laneOf(lanes, msgs("/cosmos.bank.v1beta1.MsgSend"), []string{"cosmos1other", "cosmos1oracle"}))
+	// opaque txs have nothing to match on
+	require.Equal(t, DefaultLane, laneOf(lanes, nil, nil))
+}
+
+func TestAuctionBid(t *testing.T) {
+	bundle := [][]byte{sdkTx(t, "/cosmos.bank.v1beta1.MsgSend", nil), sdkTx(t, "/cosmos.bank.v1beta1.MsgSend", []byte{1})}
+	bidTx := sdkTx(t, msgAuctionBidTypeURL, auctionBid("cosmos1searcher", "uatom", "1500", bundle...))
+	decoded, err := decodeTransaction(bidTx)
+	require.NoError(t, err)
+
+	bid := unwrapAuctionBid(decoded)
+	require.NotNil(t, bid)
+	require.Equal(t, "cosmos1searcher", bid.Bidder)
+	require.Equal(t, "uatom", bid.Denom)
+	require.Equal(t, big.NewInt(1500), bid.Amount)
+	require.Equal(t, []string{TxHash(bundle[0]), TxHash(bundle[1])}, bid.Bundle)
+	require.Equal(t, "bid 1.5Kuatom/2", formatBid(bid))
+
+	_, err = decodeMsgAuctionBid(appendBytes(nil, 2, appendString(nil, 2, "lots")))
+	require.Error(t, err)
+
+	// the winning bid comes first in the block, followed by its bundle
+	block := &cmttypes.Block{Header: cmttypes.Header{Height: 42}, Data: cmttypes.Data{Txs: cmttypes.Txs{bidTx, bundle[0], bundle[1]}}}
+	result, ok := blockAuction(block)
+	require.True(t, ok)
+	require.Equal(t, int64(42), result.Height)
+	require.Equal(t, TxHash(bidTx), result.BidHash)
+	require.True(t, result.Bundled)
+	require.Equal(t, "✓ H42 | cosmos1searcher | bid 1.5Kuatom/2", auctionLine(result))
+
+	block.Txs = cmttypes.Txs{bidTx, bundle[1], bundle[0]}
+	result, ok = blockAuction(block)
+	require.True(t, ok)
+	require.False(t, result.Bundled)
+	result.Height = 28123456
+	result.Bid.Bidder = "osmo1qxyzabcdefghijklmnopqrstuvwxyza1v7xu"
+	require.Equal(t, "⚠ H28123456 | osmo1qxyzabc..a1v7xu | bid 1.5Kuatom/2", auctionLine(result))
+	require.LessOrEqual(t, lipgloss.Width(auctionLine(result)), lineWidth)
+
+	block.Txs = cmttypes.Txs{bundle[0], bidTx}
+	_, ok = blockAuction(block)
+	require.False(t, ok)
+}
+
+func TestAuctionEvents(t *testing.T) {
+	c, err := NewCosmosModel(nil, "http://localhost:26657", time.Second, Options{})
+	require.NoError(t, err)
+	require.Equal(t, DefaultLanes, c.lanes)
+
+	bundled := sdkTx(t, "/cosmos.bank.v1beta1.MsgSend", nil)
+	bidTx := sdkTx(t, msgAuctionBidTypeURL, auctionBid("cosmos1searcher", "uatom", "10", bundled))
+	c.onBlock(&cmttypes.Block{Header: cmttypes.Header{Height: 10}, Data: cmttypes.Data{Txs: cmttypes.Txs{bidTx, bundled}}}, nil)
+	c.onBlock(&cmttypes.Block{Header: cmttypes.Header{Height: 11}, Data: cmttypes.Data{Txs: cmttypes.Txs{bundled}}}, nil)
+	require.Len(t, c.recentAuctions, 1)
+	require.Equal(t, int64(10), c.recentAuctions[0].Height)
+
+	txs := map[string]*CosmosTransaction{
+		"A": {Hash: "A", Status: StatusTypeInMempool, Lane: "mev", Raw: make([]byte, 100)},
+		"B": {Hash: "B", Status: StatusTypeInMempool, Lane: DefaultLane, Raw: make([]byte, 50)},
+		"C": {Hash: "C", Status: StatusTypeInMempool, Lane: DefaultLane, Raw: make([]byte, 50)},
+	}
+	require.Equal(t, []LaneSummary{{Name: "mev", Txs: 1, Bytes: 100}, {Name: DefaultLane, Txs: 2, Bytes: 100}}, summarizeLanes(c.lanes, txs))
+}
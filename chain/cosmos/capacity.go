@@ -0,0 +1,199 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+const (
+	// recentBlocks is the number of blocks block fullness is averaged over.
+	recentBlocks = 20
+	// capacityRefreshBlocks is how often consensus params are queried again.
+	capacityRefreshBlocks = 100
+)
+
+// Capacity is the most a block can hold, from the consensus params. MaxGas is -1 when gas is not limited.
+type Capacity struct {
+	MaxBytes int64
+	MaxGas   int64
+}
+
+// BlockUsage is how much of a block its txs used.
+type BlockUsage struct {
+	Height int64
+	Bytes  int64
+	Gas    int64
+}
+
+// blockUsage sums the tx bytes and gas used of a block.
+func blockUsage(block *cmttypes.Block, results []*abci.ExecTxResult) BlockUsage {
+	usage := BlockUsage{Height: block.Height}
+	for _, tx := range block.Txs {
+		usage.Bytes += int64(len(tx))
+	}
+	for _, result := range results {
+		usage.Gas += result.GasUsed
+	}
+	return usage
+}
+
+// recordUsage appends the usage of a block to the recent blocks, in height order.
+// Must be called with c.mu held.
+func (c *CosmosModel) recordUsage(usage BlockUsage) {
+	if n := len(c.recentUsage); n > 0 && usage.Height <= c.recentUsage[n-1].Height {
+		return
+	}
+	c.recentUsage = append(c.recentUsage, usage)
+	if len(c.recentUsage) > recentBlocks {
+		c.recentUsage = c.recentUsage[len(c.recentUsage)-recentBlocks:]
+	}
+}
+
+// observeUsage queries the usage of the block at height when block events are not delivering it.
+func (c *CosmosModel) observeUsage(ctx context.Context, height int64) {
+	if height <= 0 || c.eventsLive() {
+		return
+	}
+	c.mu.Lock()
+	seen := len(c.recentUsage) > 0 && c.recentUsage[len(c.recentUsage)-1].Height >= height
+	c.mu.Unlock()
+	if seen {
+		return
+	}
+	usage, err := c.client.BlockUsage(ctx, height)
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	c.recordUsage(usage)
+	c.mu.Unlock()
+}
+
+// refreshCapacity queries the consensus params when unknown, and every capacityRefreshBlocks blocks as governance may change them.
+func (c *CosmosModel) refreshCapacity(ctx context.Context, height int64) {
+	if c.capacityHeight > 0 && height-c.capacityHeight < capacityRefreshBlocks {
+		return
+	}
+	capacity, err := c.client.ConsensusParams(ctx)
+	if err != nil {
+		return
+	}
+	c.capacity, c.capacityHeight = capacity, max(height, 1)
+}
+
+// mempoolDemand returns the bytes and gas wanted by the whole mempool. Only the first page of txs can be listed,
+// so when truncated the gas of the listed txs is scaled up to the true number of txs.
+func mempoolDemand(txs map[string]*CosmosTransaction, size MempoolSize, truncated bool) (bytes, gas int64) {
+	var listed int64
+	for _, tx := range txs {
+		if tx.OffPage {
+			continue
+		}
+		listed++
+		bytes += int64(len(tx.Raw))
+		gas += int64(gasLimit(tx.Tx))
+	}
+	if size.Txs > 0 {
+		bytes = size.Bytes
+	}
+	if truncated && listed > 0 && int64(size.Txs) > listed {
+		gas = gas * int64(size.Txs) / listed
+	}
+	return bytes, gas
+}
+
+// Forecast is how long the mempool takes to clear.
+type Forecast struct {
+	// Blocks is the number of full blocks the mempool fills, 0 if the capacity is unknown.
+	Blocks int64
+	// BytesCap and GasCap are the per-block limits used, 0 if unknown.
+	BytesCap int64
+	GasCap   int64
+	// Fullness is the average share of the capacity recent blocks used, -1 if unknown.
+	Fullness float64
+}
+
+// Overloaded reports whether the mempool holds more than a block can include.
+func (f Forecast) Overloaded() bool {
+	return f.Blocks > 1
+}
+
+// forecast compares the demand of the mempool with the block capacity. A limit the consensus params leave
+// unbounded is replaced by the most any recent block used, which is the throughput the chain has shown.
+func forecast(demandBytes, demandGas int64, capacity Capacity, recent []BlockUsage) Forecast {
+	var maxBytesSeen, maxGasSeen int64
+	for _, usage := range recent {
+		maxBytesSeen = max(maxBytesSeen, usage.Bytes)
+		maxGasSeen = max(maxGasSeen, usage.Gas)
+	}
+
+	f := Forecast{BytesCap: capacity.MaxBytes, GasCap: capacity.MaxGas, Fullness: -1}
+	if f.BytesCap <= 0 {
+		f.BytesCap = maxBytesSeen
+	}
+	if f.GasCap <= 0 {
+		f.GasCap = maxGasSeen
+	}
+
+	if f.BytesCap > 0 {
+		f.Blocks = ceilDiv(demandBytes, f.BytesCap)
+	}
+	if f.GasCap > 0 {
+		f.Blocks = max(f.Blocks, ceilDiv(demandGas, f.GasCap))
+	}
+
+	if len(recent) > 0 && (f.BytesCap > 0 || f.GasCap > 0) {
+		var total float64
+		for _, usage := range recent {
+			total += max(share(usage.Bytes, f.BytesCap), share(usage.Gas, f.GasCap))
+		}
+		f.Fullness = total / float64(len(recent))
+	}
+	return f
+}
+
+func ceilDiv(a, b int64) int64 {
+	return (a + b - 1) / b
+}
+
+func share(used, capacity int64) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+	return float64(used) / float64(capacity)
+}
+
+// capacityLines renders the forecast and the usage of the latest blocks for the capacity box.
+func capacityLines(f Forecast, demandBytes, demandGas int64, recent []BlockUsage) []string {
+	title := fmt.Sprintf("Capacity (≈%d blocks to clear)", f.Blocks)
+	lines := []string{title, strings.Repeat("-", 50)}
+	if f.Overloaded() {
+		lines[0] = evictedStyleCosmos.Render(truncate(title+" ⚠ demand exceeds a block", lineWidth))
+	}
+
+	lines = append(lines, fmt.Sprintf("demand %s | %s gas", formatBytes(int(demandBytes)), formatGas(uint64(demandGas))))
+	limit := func(v int64) string {
+		if v <= 0 {
+			return "∞"
+		}
+		return formatGas(uint64(v))
+	}
+	lines = append(lines, fmt.Sprintf("block max %s | %s gas", formatBytes(int(f.BytesCap)), limit(f.GasCap)))
+	if f.Fullness >= 0 {
+		lines = append(lines, fmt.Sprintf("last %d blocks %.0f%% full", len(recent), f.Fullness*100))
+	}
+	for i := len(recent) - 1; i >= 0 && len(lines) < 10; i-- {
+		usage := recent[i]
+		full := max(share(usage.Bytes, f.BytesCap), share(usage.Gas, f.GasCap))
+		lines = append(lines, fadedStyleCosmos.Render(fmt.Sprintf("H%d | %s | %s gas | %.0f%%",
+			usage.Height, formatBytes(int(usage.Bytes)), formatGas(uint64(usage.Gas)), full*100)))
+	}
+	for len(lines) < 10 {
+		lines = append(lines, "")
+	}
+	return lines
+}
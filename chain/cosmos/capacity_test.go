@@ -0,0 +1,54 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForecast(t *testing.T) {
+	recent := []BlockUsage{
+		{Height: 1, Bytes: 500, Gas: 10_000},
+		{Height: 2, Bytes: 1000, Gas: 40_000},
+	}
+
+	// gas bound: 250k gas over 100k blocks
+	f := forecast(1500, 250_000, Capacity{MaxBytes: 2000, MaxGas: 100_000}, recent)
+	require.Equal(t, int64(3), f.Blocks)
+	require.True(t, f.Overloaded())
+	require.InDelta(t, 0.375, f.Fullness, 1e-9) // (25% bytes + 50% bytes) / 2
+
+	// unlimited gas falls back to the most gas a recent block used
+	f = forecast(100, 100_000, Capacity{MaxBytes: 2000, MaxGas: -1}, recent)
+	require.Equal(t, int64(40_000), f.GasCap)
+	require.Equal(t, int64(3), f.Blocks)
+
+	f = forecast(100, 1000, Capacity{MaxBytes: 2000, MaxGas: 100_000}, nil)
+	require.Equal(t, int64(1), f.Blocks)
+	require.False(t, f.Overloaded())
+	require.Equal(t, float64(-1), f.Fullness)
+
+	f = forecast(100, 1000, Capacity{}, nil)
+	require.Zero(t, f.Blocks)
+}
+
+func TestMempoolDemand(t *testing.T) {
+	withGas := func(gas uint64) *tx.Tx {
+		return &tx.Tx{AuthInfo: &tx.AuthInfo{Fee: &tx.Fee{GasLimit: gas}}}
+	}
+	txs := map[string]*CosmosTransaction{
+		"A": {Hash: "A", Raw: make([]byte, 100), Tx: withGas(1000)},
+		"B": {Hash: "B", Raw: make([]byte, 50), Tx: withGas(3000)},
+		"C": {Hash: "C", Raw: make([]byte, 70), Tx: withGas(5000), OffPage: true},
+	}
+
+	bytes, gas := mempoolDemand(txs, MempoolSize{}, false)
+	require.Equal(t, int64(150), bytes)
+	require.Equal(t, int64(4000), gas)
+
+	// two of ten txs listed
+	bytes, gas = mempoolDemand(txs, MempoolSize{Txs: 10, Bytes: 900}, true)
+	require.Equal(t, int64(900), bytes)
+	require.Equal(t, int64(20_000), gas)
+}
@@ -0,0 +1,220 @@
+package cosmos
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// blobTxTypeID marks a Celestia BlobTx{tx = 1, blobs = 2, type_id = 3} in the mempool.
+	blobTxTypeID = "BLOB"
+	// indexWrapperTypeID marks a Celestia IndexWrapper{tx = 1, share_indexes = 2, type_id = 3}, a BlobTx once in a block.
+	indexWrapperTypeID = "INDX"
+	// namespaceSize is the size of a Celestia namespace: a version byte and a 28 byte id.
+	namespaceSize = 29
+)
+
+// sdkTxBytes returns the sdk tx wrapped in a Celestia BlobTx or IndexWrapper, or raw itself for any other tx.
+func sdkTxBytes(raw []byte) []byte {
+	wrapper, err := parseProto(raw)
+	if err != nil {
+		return raw
+	}
+	switch wrapper.str(3) {
+	case blobTxTypeID, indexWrapperTypeID:
+		if inner := wrapper.field(1); inner != nil {
+			return inner
+		}
+	}
+	return raw
+}
+
+// PayForBlobs is a decoded Celestia MsgPayForBlobs.
+type PayForBlobs struct {
+	Signer string
+	Blobs  []Blob
+}
+
+// Blob is a blob paid for by a MsgPayForBlobs.
+type Blob struct {
+	Namespace    []byte
+	Size         uint32
+	Commitment   []byte
+	ShareVersion uint32
+}
+
+// Size returns the total size of the blobs.
+func (p *PayForBlobs) Size() int64 {
+	var size int64
+	for _, blob := range p.Blobs {
+		size += int64(blob.Size)
+	}
+	return size
+}
+
+func isMsgPayForBlobs(typeURL string) bool {
+	return typeURL == "/celestia.blob.v1.MsgPayForBlobs"
+}
+
+// unwrapPayForBlobs returns the first MsgPayForBlobs of a tx, or nil if it has none that decodes.
+// Like MsgEthereumTx it is decoded by hand, to not depend on celestia-app.
+func unwrapPayForBlobs(tx *tx.Tx) *PayForBlobs {
+	if tx == nil || tx.Body == nil {
+		return nil
+	}
+	for _, msg := range tx.Body.Messages {
+		if !isMsgPayForBlobs(msg.TypeUrl) {
+			continue
+		}
+		if pfb, err := decodeMsgPayForBlobs(msg.Value); err == nil {
+			return pfb
+		}
+	}
+	return nil
+}
+
+// decodeMsgPayForBlobs decodes MsgPayForBlobs{signer = 1, namespaces = 2, blob_sizes = 3, share_commitments = 4,
+// share_versions = 8}, whose repeated fields are indexed together.
+func decodeMsgPayForBlobs(b []byte) (*PayForBlobs, error) {
+	m, err := parseProto(b)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := m.bytes[2]
+	sizes, err := m.packedVarints(3)
+	if err != nil {
+		return nil, err
+	}
+	commitments := m.bytes[4]
+	versions, err := m.packedVarints(8)
+	if err != nil {
+		return nil, err
+	}
+	if len(sizes) != len(namespaces) || len(commitments) != len(namespaces) {
+		return nil, fmt.Errorf("%d namespaces with %d blob sizes and %d share commitments", len(namespaces), len(sizes), len(commitments))
+	}
+
+	pfb := &PayForBlobs{Signer: m.str(1), Blobs: make([]Blob, len(namespaces))}
+	for i, namespace := range namespaces {
+		pfb.Blobs[i] = Blob{Namespace: namespace, Size: uint32(sizes[i]), Commitment: commitments[i]}
+		if i < len(versions) {
+			pfb.Blobs[i].ShareVersion = uint32(versions[i])
+		}
+	}
+	return pfb, nil
+}
+
+// packedVarints decodes a repeated varint field, packed or not.
+func (m protoMessage) packedVarints(num protowire.Number) ([]uint64, error) {
+	var values []uint64
+	for _, packed := range m.bytes[num] {
+		for len(packed) > 0 {
+			v, n := protowire.ConsumeVarint(packed)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			values = append(values, v)
+			packed = packed[n:]
+		}
+	}
+	if v, ok := m.varints[num]; ok && len(values) == 0 {
+		// parseProto keeps only the last of unpacked values, which is enough for single blob txs
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// formatNamespace renders a namespace as the hex of its id without the leading zeros of version 0 namespaces.
+func formatNamespace(namespace []byte) string {
+	if len(namespace) != namespaceSize {
+		return hex.EncodeToString(namespace)
+	}
+	id := bytes.TrimLeft(namespace[1:], "\x00")
+	s := hex.EncodeToString(id)
+	if namespace[0] != 0 {
+		s = fmt.Sprintf("v%d:%s", namespace[0], s)
+	}
+	return s
+}
+
+// formatBlobs summarizes the blobs of a tx for the message type column, e.g. "blob×2 1.2MB".
+func formatBlobs(pfb *PayForBlobs) string {
+	return fmt.Sprintf("blob×%d %s", len(pfb.Blobs), formatBytes(int(pfb.Size())))
+}
+
+// blobDetailLines renders a MsgPayForBlobs for the detail box.
+func blobDetailLines(pfb *PayForBlobs) []string {
+	lines := []string{
+		inMempoolStyleCosmos.Render("MsgPayForBlobs"),
+		"signer " + pfb.Signer,
+	}
+	for _, blob := range pfb.Blobs {
+		lines = append(lines, fmt.Sprintf("ns %s | %s | v%d | commit %s",
+			truncate(formatNamespace(blob.Namespace), 16), formatBytes(int(blob.Size)), blob.ShareVersion,
+			truncateHash(hex.EncodeToString(blob.Commitment))))
+	}
+	return lines
+}
+
+// NamespaceSummary is the pending blob data of a namespace.
+type NamespaceSummary struct {
+	Namespace string
+	Txs       int
+	Blobs     int
+	Bytes     int64
+	Signers   int
+}
+
+// summarizeNamespaces totals the blobs of pending txs by namespace, most bytes first.
+func summarizeNamespaces(txs map[string]*CosmosTransaction) []NamespaceSummary {
+	namespaces := make(map[string]*NamespaceSummary)
+	signers := make(map[string]map[string]bool)
+	for _, tx := range txs {
+		if tx.Blobs == nil || tx.Status != StatusTypeInMempool {
+			continue
+		}
+		counted := make(map[string]bool)
+		for _, blob := range tx.Blobs.Blobs {
+			namespace := formatNamespace(blob.Namespace)
+			summary, ok := namespaces[namespace]
+			if !ok {
+				summary = &NamespaceSummary{Namespace: namespace}
+				namespaces[namespace] = summary
+				signers[namespace] = make(map[string]bool)
+			}
+			if !counted[namespace] {
+				counted[namespace] = true
+				summary.Txs++
+			}
+			summary.Blobs++
+			summary.Bytes += int64(blob.Size)
+			signers[namespace][tx.Blobs.Signer] = true
+		}
+	}
+
+	summaries := make([]NamespaceSummary, 0, len(namespaces))
+	for namespace, summary := range namespaces {
+		summary.Signers = len(signers[namespace])
+		summaries = append(summaries, *summary)
+	}
+	slices.SortFunc(summaries, func(a, b NamespaceSummary) int {
+		if order := cmp.Compare(b.Bytes, a.Bytes); order != 0 {
+			return order
+		}
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+	return summaries
+}
+
+// namespaceLine renders a namespace summary as a row of the blobs box.
+func namespaceLine(summary NamespaceSummary) string {
+	return truncate(fmt.Sprintf("%s | %s | %d blobs | %d txs | %d signers",
+		truncate(summary.Namespace, 16), formatBytes(int(summary.Bytes)), summary.Blobs, summary.Txs, summary.Signers), lineWidth)
+}
@@ -0,0 +1,91 @@
+package cosmos
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func namespace(id byte) []byte {
+	ns := make([]byte, namespaceSize)
+	ns[namespaceSize-1] = id
+	return ns
+}
+
+// payForBlobs encodes a MsgPayForBlobs with packed blob sizes and share versions.
+func payForBlobs(signer string, namespaces [][]byte, sizes []uint64) []byte {
+	var msg, packedSizes, packedVersions []byte
+	msg = appendString(msg, 1, signer)
+	for i, ns := range namespaces {
+		msg = appendBytes(msg, 2, ns)
+		packedSizes = protowire.AppendVarint(packedSizes, sizes[i])
+		packedVersions = protowire.AppendVarint(packedVersions, 0)
+	}
+	msg = appendBytes(msg, 3, packedSizes)
+	for i := range namespaces {
+		msg = appendBytes(msg, 4, bytes.Repeat([]byte{byte(i + 1)}, 32))
+	}
+	return appendBytes(msg, 8, packedVersions)
+}
+
+func TestBlobTx(t *testing.T) {
+	sdkTx := tx.Tx{Body: &tx.TxBody{Messages: []*codectypes.Any{{
+		TypeUrl: "/celestia.blob.v1.MsgPayForBlobs",
+		Value:   payForBlobs("celestia1signer", [][]byte{namespace(0xaa), namespace(0xbb)}, []uint64{1000, 24}),
+	}}}}
+	inner, err := sdkTx.Marshal()
+	require.NoError(t, err)
+
+	var blobTx []byte
+	blobTx = appendBytes(blobTx, 1, inner)
+	blobTx = appendBytes(blobTx, 2, appendBytes(appendBytes(nil, 1, namespace(0xaa)), 2, make([]byte, 1000)))
+	blobTx = appendString(blobTx, 3, blobTxTypeID)
+
+	// hashed and decoded without the blobs, as celestia-core does
+	require.Equal(t, TxHash(inner), TxHash(blobTx))
+	decoded, err := decodeTransaction(blobTx)
+	require.NoError(t, err)
+
+	pfb := unwrapPayForBlobs(decoded)
+	require.NotNil(t, pfb)
+	require.Equal(t, "celestia1signer", pfb.Signer)
+	require.Len(t, pfb.Blobs, 2)
+	require.Equal(t, uint32(1000), pfb.Blobs[0].Size)
+	require.Equal(t, bytes.Repeat([]byte{2}, 32), pfb.Blobs[1].Commitment)
+	require.Equal(t, "blob×2 1.0KB", formatBlobs(pfb))
+	require.Equal(t, "aa", formatNamespace(pfb.Blobs[0].Namespace))
+
+	_, err = decodeMsgPayForBlobs(appendBytes(nil, 2, namespace(1)))
+	require.Error(t, err)
+}
+
+func TestSummarizeNamespaces(t *testing.T) {
+	blobs := func(signer string, sizes ...uint32) *PayForBlobs {
+		pfb := &PayForBlobs{Signer: signer}
+		for i, size := range sizes {
+			pfb.Blobs = append(pfb.Blobs, Blob{Namespace: namespace(byte(i + 1)), Size: size})
+		}
+		return pfb
+	}
+	txs := map[string]*CosmosTransaction{
+		"A": {Hash: "A", Status: StatusTypeInMempool, Blobs: blobs("alice", 100, 5000)},
+		"B": {Hash: "B", Status: StatusTypeInMempool, Blobs: blobs("bob", 300)},
+		"C": {Hash: "C", Status: StatusTypeInMempool},
+	}
+
+	summaries := summarizeNamespaces(txs)
+	require.Equal(t, []NamespaceSummary{
+		{Namespace: "02", Txs: 1, Blobs: 1, Bytes: 5000, Signers: 1},
+		{Namespace: "01", Txs: 2, Blobs: 2, Bytes: 400, Signers: 2},
+	}, summaries)
+	require.Equal(t, "01 | 400B | 2 blobs | 2 txs | 2 signers", namespaceLine(summaries[1]))
+	// a typical 10 byte namespace id
+	busy := NamespaceSummary{Namespace: "0000000000736f762d6d", Txs: 120, Blobs: 240, Bytes: 1_500_000, Signers: 12}
+	require.LessOrEqual(t, lipgloss.Width(namespaceLine(busy)), lineWidth)
+	require.Equal(t, "v1:07", formatNamespace(append([]byte{1}, namespace(7)[1:]...)))
+}
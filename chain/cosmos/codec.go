@@ -0,0 +1,178 @@
+package cosmos
+
+import (
+	"fmt"
+	"strings"
+
+	"cosmossdk.io/x/tx/signing"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/address"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/std"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	ibctypes "github.com/cosmos/ibc-go/v8/modules/core/types"
+	ibctm "github.com/cosmos/ibc-go/v8/modules/light-clients/07-tendermint"
+)
+
+// DefaultBech32Prefix is the account address prefix used when none is configured.
+const DefaultBech32Prefix = "cosmos"
+
+// DecodedMsg is a tx message unpacked through the InterfaceRegistry.
+type DecodedMsg struct {
+	TypeURL string
+	// Msg is nil when the message type is not registered.
+	Msg sdk.Msg
+}
+
+// Codec decodes tx messages and signers of the standard Cosmos SDK, IBC and wasm modules.
+type Codec struct {
+	registry     codectypes.InterfaceRegistry
+	cdc          *codec.ProtoCodec
+	bech32Prefix string
+}
+
+// NewCodec returns a Codec preloaded with the bank, staking, gov, distribution, authz, ibc and wasm modules,
+// rendering signer addresses with bech32Prefix.
+func NewCodec(bech32Prefix string) (*Codec, error) {
+	if bech32Prefix == "" {
+		bech32Prefix = DefaultBech32Prefix
+	}
+
+	// address codecs with the chain's prefix let the registry find the signers of messages, such as those of authz grants
+	registry, err := codectypes.NewInterfaceRegistryWithOptions(codectypes.InterfaceRegistryOptions{
+		ProtoFiles: gogoproto.HybridResolver,
+		SigningOptions: signing.Options{
+			AddressCodec:          address.NewBech32Codec(bech32Prefix),
+			ValidatorAddressCodec: address.NewBech32Codec(bech32Prefix + sdk.PrefixValidator + sdk.PrefixOperator),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create interface registry: %w", err)
+	}
+	std.RegisterInterfaces(registry)
+	authtypes.RegisterInterfaces(registry)
+	vestingtypes.RegisterInterfaces(registry)
+	banktypes.RegisterInterfaces(registry)
+	stakingtypes.RegisterInterfaces(registry)
+	govv1.RegisterInterfaces(registry)
+	govv1beta1.RegisterInterfaces(registry)
+	distrtypes.RegisterInterfaces(registry)
+	authz.RegisterInterfaces(registry)
+	ibctypes.RegisterInterfaces(registry)
+	ibctm.RegisterInterfaces(registry)
+	transfertypes.RegisterInterfaces(registry)
+	wasmtypes.RegisterInterfaces(registry)
+
+	return &Codec{
+		registry:     registry,
+		cdc:          codec.NewProtoCodec(registry),
+		bech32Prefix: bech32Prefix,
+	}, nil
+}
+
+// DecodeMsgs unpacks every message of the tx. Messages of unregistered types are kept with a nil Msg.
+func (c *Codec) DecodeMsgs(tx *tx.Tx) []DecodedMsg {
+	if tx == nil || tx.Body == nil {
+		return nil
+	}
+	msgs := make([]DecodedMsg, len(tx.Body.Messages))
+	for i, any := range tx.Body.Messages {
+		msgs[i].TypeURL = any.TypeUrl
+		var msg sdk.Msg
+		if err := c.registry.UnpackAny(any, &msg); err == nil {
+			msgs[i].Msg = msg
+		}
+	}
+	return msgs
+}
+
+// Signers returns the bech32 address of every signer of the tx, derived from the signer public keys.
+// Signers whose key can't be decoded are returned as "?".
+func (c *Codec) Signers(tx *tx.Tx) []string {
+	if tx == nil || tx.AuthInfo == nil {
+		return nil
+	}
+	signers := make([]string, len(tx.AuthInfo.SignerInfos))
+	for i, info := range tx.AuthInfo.SignerInfos {
+		signers[i] = "?"
+		if info.PublicKey == nil {
+			continue
+		}
+		var pk cryptotypes.PubKey
+		if err := c.registry.UnpackAny(info.PublicKey, &pk); err != nil {
+			continue
+		}
+		if addr, err := bech32.ConvertAndEncode(c.bech32Prefix, pk.Address()); err == nil {
+			signers[i] = addr
+		}
+	}
+	return signers
+}
+
+// MsgSigners returns the bech32 addresses of the signers of a message, from its cosmos.msg.v1.signer option.
+// It returns nil if the message type is not registered or its signers can't be read.
+func (c *Codec) MsgSigners(msg DecodedMsg) []string {
+	if msg.Msg == nil {
+		return nil
+	}
+	signers, _, err := c.cdc.GetMsgV1Signers(msg.Msg)
+	if err != nil {
+		return nil
+	}
+	addrs := make([]string, 0, len(signers))
+	for _, signer := range signers {
+		if addr, err := bech32.ConvertAndEncode(c.bech32Prefix, signer); err == nil {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// AccountSequence returns the next sequence of an account returned by the x/auth Account query.
+func (c *Codec) AccountSequence(account *codectypes.Any) (uint64, error) {
+	var acc sdk.AccountI
+	if err := c.registry.UnpackAny(account, &acc); err != nil {
+		return 0, err
+	}
+	return acc.GetSequence(), nil
+}
+
+// MsgJSON renders the fields of a message as JSON, or a placeholder if its type is not registered.
+func (c *Codec) MsgJSON(msg DecodedMsg) string {
+	if msg.Msg == nil {
+		return "(unregistered type)"
+	}
+	bz, err := c.cdc.MarshalJSON(msg.Msg)
+	if err != nil {
+		return "(" + err.Error() + ")"
+	}
+	return string(bz)
+}
+
+// wrapLines splits s into lines of at most width characters.
+func wrapLines(s string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		runes := []rune(paragraph)
+		for len(runes) > width {
+			lines = append(lines, string(runes[:width]))
+			runes = runes[width:]
+		}
+		lines = append(lines, string(runes))
+	}
+	return lines
+}
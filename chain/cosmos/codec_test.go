@@ -0,0 +1,58 @@
+package cosmos
+
+import (
+	"strings"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+func testTx(t *testing.T, msgs ...sdk.Msg) (*tx.Tx, sdk.AccAddress) {
+	t.Helper()
+
+	anys := make([]*codectypes.Any, len(msgs))
+	for i, msg := range msgs {
+		any, err := codectypes.NewAnyWithValue(msg)
+		require.NoError(t, err)
+		anys[i] = any
+	}
+
+	pk := secp256k1.GenPrivKey().PubKey()
+	pkAny, err := codectypes.NewAnyWithValue(pk)
+	require.NoError(t, err)
+
+	return &tx.Tx{
+		Body: &tx.TxBody{Messages: anys},
+		AuthInfo: &tx.AuthInfo{
+			SignerInfos: []*tx.SignerInfo{{PublicKey: pkAny, Sequence: 3}},
+			Fee:         &tx.Fee{GasLimit: 200000},
+		},
+	}, sdk.AccAddress(pk.Address())
+}
+
+func TestCodecDecode(t *testing.T) {
+	send := &banktypes.MsgSend{FromAddress: "a", ToAddress: "b", Amount: sdk.NewCoins(sdk.NewInt64Coin("uatom", 5))}
+	unknown := &codectypes.Any{TypeUrl: "/unknown.v1.MsgFoo"}
+
+	tx, addr := testTx(t, send)
+	tx.Body.Messages = append(tx.Body.Messages, unknown)
+
+	c, err := NewCodec("osmo")
+	require.NoError(t, err)
+	msgs := c.DecodeMsgs(tx)
+	require.Len(t, msgs, 2)
+	require.Equal(t, "/cosmos.bank.v1beta1.MsgSend", msgs[0].TypeURL)
+	require.Equal(t, send, msgs[0].Msg)
+	require.Nil(t, msgs[1].Msg)
+	require.Contains(t, c.MsgJSON(msgs[0]), `"to_address":"b"`)
+
+	signers := c.Signers(tx)
+	require.Len(t, signers, 1)
+	require.True(t, strings.HasPrefix(signers[0], "osmo1"))
+	require.Equal(t, sdk.MustBech32ifyAddressBytes("osmo", addr), signers[0])
+}
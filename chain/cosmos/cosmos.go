@@ -2,15 +2,18 @@ package cosmos
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
 
 	"github.com/cometbft/cometbft/rpc/client/http"
+	cmttypes "github.com/cometbft/cometbft/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 )
 
 type CosmosRPCClient struct {
 	client *http.HTTP
+	batch  *batchClient
 }
 
 func NewCosmosRPCClient(endpoint string) (*CosmosRPCClient, error) {
@@ -18,72 +21,172 @@ func NewCosmosRPCClient(endpoint string) (*CosmosRPCClient, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create RPC client: %w", err)
 	}
-	return &CosmosRPCClient{client: client}, nil
+	batch, err := newBatchClient(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create batch RPC client: %w", err)
+	}
+	return &CosmosRPCClient{client: client, batch: batch}, nil
+}
+
+// MempoolSize is the true size of the mempool, of which unconfirmed_txs only returns the first page.
+type MempoolSize struct {
+	Txs   int
+	Bytes int64
 }
 
-func (c *CosmosRPCClient) MempoolTxs(ctx context.Context, limit int) ([]*tx.Tx, error) {
+// RawMempoolTxs returns the undecoded bytes of up to limit unconfirmed txs, along with the size of the whole mempool
+// reported by the same query.
+func (c *CosmosRPCClient) RawMempoolTxs(ctx context.Context, limit int) ([][]byte, MempoolSize, error) {
 	result, err := c.client.UnconfirmedTxs(ctx, &limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query unconfirmed txs: %w", err)
+		return nil, MempoolSize{}, fmt.Errorf("failed to query unconfirmed txs: %w", err)
 	}
 
-	txs := make([]*tx.Tx, 0, len(result.Txs))
-	for _, txData := range result.Txs {
-		decodedTx, err := decodeTransaction(txData)
-		if err != nil {
-			// log.Println("failed to decode tx: ", err.Error())
-			continue
-		}
-		txs = append(txs, decodedTx)
+	txs := make([][]byte, len(result.Txs))
+	for i, txData := range result.Txs {
+		txs[i] = txData
 	}
-	return txs, nil
+	return txs, MempoolSize{Txs: result.Total, Bytes: result.TotalBytes}, nil
+}
+
+// MempoolTx is an unconfirmed tx along with the raw bytes it was decoded from.
+type MempoolTx struct {
+	// Hash is computed from the raw bytes, exactly as CometBFT does.
+	Hash string
+	Raw  []byte
+	// Tx is nil if the raw bytes could not be decoded, DecodeErr says why.
+	Tx        *tx.Tx
+	DecodeErr error
+}
+
+// TxHash returns the CometBFT hash of raw tx bytes: uppercase hex of their sha256, without 0x prefix.
+// Like celestia-core, Celestia blob txs are hashed without their blobs.
+func TxHash(raw []byte) string {
+	return fmt.Sprintf("%X", cmttypes.Tx(sdkTxBytes(raw)).Hash())
+}
+
+// MempoolTxs returns up to limit unconfirmed txs and the size of the whole mempool. Txs that fail to decode are
+// returned with a nil Tx.
+func (c *CosmosRPCClient) MempoolTxs(ctx context.Context, limit int) ([]*MempoolTx, MempoolSize, error) {
+	rawTxs, size, err := c.RawMempoolTxs(ctx, limit)
+	if err != nil {
+		return nil, MempoolSize{}, err
+	}
+
+	txs := make([]*MempoolTx, 0, len(rawTxs))
+	for _, txData := range rawTxs {
+		mempoolTx := &MempoolTx{Hash: TxHash(txData), Raw: txData}
+		mempoolTx.Tx, mempoolTx.DecodeErr = decodeTransaction(txData)
+		txs = append(txs, mempoolTx)
+	}
+	return txs, size, nil
 }
 
 func decodeTransaction(txBytes []byte) (*tx.Tx, error) {
 	var decodedTx tx.Tx
-	if err := decodedTx.Unmarshal(txBytes); err != nil {
+	if err := decodedTx.Unmarshal(sdkTxBytes(txBytes)); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal transaction: %w", err)
 	}
 
 	return &decodedTx, nil
 }
 
-// TxStatus queries the status of a transaction by hash
-func (c *CosmosRPCClient) TxStatus(ctx context.Context, txHash string) (*TxResult, error) {
-	// Convert hex string to bytes for CometBFT query
-	hashBytes, err := hex.DecodeString(txHash)
+type TxResult struct {
+	TxResult interface{} // The actual transaction result from CometBFT
+	Height   int64       // Block height where tx was included
+	Index    uint32      // Index of the tx in the block
+}
+
+// LatestHeight returns the height of the latest committed block.
+func (c *CosmosRPCClient) LatestHeight(ctx context.Context) (int64, error) {
+	status, err := c.client.Status(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query status: %w", err)
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+// BlockTxHashes returns the hashes of the txs of the block at height, in block order.
+func (c *CosmosRPCClient) BlockTxHashes(ctx context.Context, height int64) ([]string, error) {
+	block, err := c.client.Block(ctx, &height)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode hex hash: %w", err)
+		return nil, fmt.Errorf("failed to query block %d: %w", height, err)
 	}
+	return blockTxHashes(block.Block), nil
+}
 
-	result, err := c.client.Tx(ctx, hashBytes, false)
+// BlockTxResult returns the result of the tx at index in the block at height.
+func (c *CosmosRPCClient) BlockTxResult(ctx context.Context, height int64, index uint32) (*TxResult, error) {
+	results, err := c.client.BlockResults(ctx, &height)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query transaction: %w", err)
+		return nil, fmt.Errorf("failed to query block results %d: %w", height, err)
+	}
+	if int(index) >= len(results.TxsResults) {
+		return nil, fmt.Errorf("block %d has no tx result %d", height, index)
 	}
+	return &TxResult{TxResult: *results.TxsResults[index], Height: height, Index: index}, nil
+}
 
-	return &TxResult{
-		TxResult: result.TxResult,
-		Height:   result.Height,
-	}, nil
+// ConsensusParams returns the block capacity from the latest consensus params.
+func (c *CosmosRPCClient) ConsensusParams(ctx context.Context) (Capacity, error) {
+	result, err := c.client.ConsensusParams(ctx, nil)
+	if err != nil {
+		return Capacity{}, fmt.Errorf("failed to query consensus params: %w", err)
+	}
+	block := result.ConsensusParams.Block
+	if block.MaxBytes == -1 {
+		// -1 lets blocks grow up to the hard limit
+		block.MaxBytes = cmttypes.MaxBlockSizeBytes
+	}
+	return Capacity{MaxBytes: block.MaxBytes, MaxGas: block.MaxGas}, nil
 }
 
-// BatchTxStatus queries multiple transaction statuses
-func (c *CosmosRPCClient) BatchTxStatus(ctx context.Context, txHashes []string) ([]*TxResult, error) {
-	results := make([]*TxResult, len(txHashes))
+// Block returns the block at height.
+func (c *CosmosRPCClient) Block(ctx context.Context, height int64) (*cmttypes.Block, error) {
+	block, err := c.client.Block(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query block %d: %w", height, err)
+	}
+	return block.Block, nil
+}
 
-	for i, hash := range txHashes {
-		result, err := c.TxStatus(ctx, hash)
-		if err != nil {
-			results[i] = nil
-		} else {
-			results[i] = result
-		}
+// BlockUsage returns the tx bytes and gas used of the block at height.
+func (c *CosmosRPCClient) BlockUsage(ctx context.Context, height int64) (BlockUsage, error) {
+	block, err := c.client.Block(ctx, &height)
+	if err != nil {
+		return BlockUsage{}, fmt.Errorf("failed to query block %d: %w", height, err)
+	}
+	results, err := c.client.BlockResults(ctx, &height)
+	if err != nil {
+		return BlockUsage{}, fmt.Errorf("failed to query block results %d: %w", height, err)
 	}
+	return blockUsage(block.Block, results.TxsResults), nil
+}
 
-	return results, nil
+func blockTxHashes(block *cmttypes.Block) []string {
+	hashes := make([]string, len(block.Txs))
+	for i, tx := range block.Txs {
+		hashes[i] = TxHash(tx)
+	}
+	return hashes
 }
 
-type TxResult struct {
-	TxResult interface{} // The actual transaction result from CometBFT
-	Height   int64       // Block height where tx was included
+// Account queries x/auth for the account at a bech32 address, as of the latest block.
+func (c *CosmosRPCClient) Account(ctx context.Context, address string) (*codectypes.Any, error) {
+	req, err := (&authtypes.QueryAccountRequest{Address: address}).Marshal()
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.client.ABCIQuery(ctx, "/cosmos.auth.v1beta1.Query/Account", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account %s: %w", address, err)
+	}
+	if !result.Response.IsOK() {
+		return nil, fmt.Errorf("failed to query account %s: %s", address, result.Response.Log)
+	}
+	var resp authtypes.QueryAccountResponse
+	if err := resp.Unmarshal(result.Response.Value); err != nil {
+		return nil, fmt.Errorf("failed to decode account %s: %w", address, err)
+	}
+	return resp.Account, nil
 }
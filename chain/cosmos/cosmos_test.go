@@ -0,0 +1,123 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxHash(t *testing.T) {
+	require.Equal(t, "BA7816BF8F01CFEA414140DE5DAE2223B00361A396177A9CB410FF61F20015AD", TxHash([]byte("abc")))
+
+	_, err := decodeTransaction([]byte{0xff, 0xff, 0xff})
+	require.Error(t, err)
+}
+
+func TestMempoolHeader(t *testing.T) {
+	c, err := NewCosmosModel(nil, "test", 0, Options{})
+	require.NoError(t, err)
+	require.Equal(t, "Mempool (0 txs)", c.mempoolHeader())
+
+	c.mempoolSize = MempoolSize{Txs: 2345, Bytes: 1200000}
+	c.pageSize = 100
+	c.truncated = true
+	require.Equal(t, "Mempool (first 100 of 2345 txs, 1.2MB)", c.mempoolHeader())
+
+	// busy and filtered, the header still fits the box
+	c.forecast = Forecast{Blocks: 3, Fullness: 1}
+	c.filter = parseFilter("type:msgsend")
+	c.decodeFailures = 12
+	require.LessOrEqual(t, lipgloss.Width(c.mempoolHeader()), lineWidth)
+	require.NotContains(t, c.mempoolHeader(), "over capacity")
+}
+
+func TestSettleOffPage(t *testing.T) {
+	c, err := NewCosmosModel(nil, "test", 0, Options{})
+	require.NoError(t, err)
+	c.included["A"] = &TxResult{TxResult: abci.ExecTxResult{}, Height: 5}
+	a := &CosmosTransaction{Hash: "A", Status: StatusTypeInMempool}
+	b := &CosmosTransaction{Hash: "B", Status: StatusTypeInMempool}
+
+	// the height is unknown, so B is not looked up and stays pending
+	included := c.settleOffPage(context.Background(), []*CosmosTransaction{a, b}, 0)
+	require.Equal(t, []*CosmosTransaction{a}, included)
+	require.Equal(t, StatusTypeSuccess, a.Status)
+	require.Equal(t, StatusTypeInMempool, b.Status)
+	require.True(t, b.OffPage)
+}
+
+func TestLeftPage(t *testing.T) {
+	fifo, err := NewCosmosModel(nil, "test", 0, Options{})
+	require.NoError(t, err)
+	priority, err := NewCosmosModel(nil, "test", 0, Options{PriorityMempool: true})
+	require.NoError(t, err)
+	current := make(map[string]*CosmosTransaction)
+	for i := range MempoolPageLimit {
+		hash := fmt.Sprintf("%064X", i)
+		current[hash] = &CosmosTransaction{Hash: hash, Status: StatusTypeInMempool, LastSeenHeight: 20}
+	}
+	pushed := &CosmosTransaction{Hash: "PUSHED", Status: StatusTypeInMempool, LastSeenHeight: 20}
+	done := &CosmosTransaction{Hash: "DONE", Status: StatusTypeSuccess}
+	for _, c := range []*CosmosModel{fifo, priority} {
+		c.transactions = maps.Clone(current)
+		c.transactions[pushed.Hash] = pushed
+		c.transactions[done.Hash] = done
+		c.completed = append(c.completed, done)
+	}
+
+	// a FIFO mempool lists its oldest txs, so a tx missing from the page was removed even if the page is truncated
+	removed, offPage := fifo.leftPage(current, true, 21)
+	require.Equal(t, []*CosmosTransaction{pushed}, removed)
+	require.Empty(t, offPage)
+
+	// a priority mempool may have pushed it off the page, until it was not seen for offPageBlocks
+	removed, offPage = priority.leftPage(current, true, 21)
+	require.Empty(t, removed)
+	require.Equal(t, []*CosmosTransaction{pushed}, offPage)
+	removed, offPage = priority.leftPage(current, true, 20+offPageBlocks+1)
+	require.Equal(t, []*CosmosTransaction{pushed}, removed)
+	require.Empty(t, offPage)
+
+	// a page that is the whole mempool
+	delete(current, fmt.Sprintf("%064X", 0))
+	removed, offPage = priority.leftPage(current, false, 21)
+	require.Len(t, removed, 2)
+	require.Empty(t, offPage)
+}
+
+func TestRowWidth(t *testing.T) {
+	send := &banktypes.MsgSend{FromAddress: "a", ToAddress: "b", Amount: sdk.NewCoins(sdk.NewInt64Coin("uatom", 5))}
+	decoded, _ := testTx(t, send)
+	decoded.AuthInfo.Fee = &tx.Fee{
+		Amount:   sdk.NewCoins(sdk.NewInt64Coin("ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", 123456789)),
+		GasLimit: 2500000,
+		Granter:  "cosmos1granter",
+	}
+	pending := &CosmosTransaction{
+		Hash:         TxHash([]byte("pending")),
+		Tx:           decoded,
+		Status:       StatusTypeInMempool,
+		SequenceFlag: SequenceGap,
+		ExpiryState:  ExpirySoon,
+	}
+	require.LessOrEqual(t, lipgloss.Width(mempoolLine(pending)), lineWidth)
+
+	result := abci.ExecTxResult{GasUsed: 1234567}
+	completed := &CosmosTransaction{
+		Hash:            TxHash([]byte("completed")),
+		Tx:              decoded,
+		Status:          StatusTypeSuccess,
+		Result:          &result,
+		HeightCompleted: 28123456,
+		IndexCompleted:  123,
+	}
+	require.LessOrEqual(t, lipgloss.Width(completedLine(completed)), lineWidth)
+}
@@ -0,0 +1,122 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+)
+
+const (
+	// eventSubscriber is ignored by CometBFT, which identifies websocket subscribers by their remote address.
+	eventSubscriber = "xray"
+	// eventsStaleAfter is how long without a NewBlock event before events are no longer trusted for inclusion.
+	eventsStaleAfter = 30 * time.Second
+	// eventGracePeriod is how long a tx that left the mempool waits for its Tx event before falling back to /tx.
+	eventGracePeriod = 3 * time.Second
+	// includedRetention is the number of blocks Tx events are kept for.
+	includedRetention = 100
+)
+
+// Subscribe starts the websocket client and subscribes to NewBlock and Tx events.
+func (c *CosmosRPCClient) Subscribe(ctx context.Context) (blocks, txs <-chan coretypes.ResultEvent, err error) {
+	if !c.client.IsRunning() {
+		if err := c.client.Start(); err != nil {
+			return nil, nil, fmt.Errorf("failed to start websocket client: %w", err)
+		}
+	}
+
+	blocks, err = c.client.Subscribe(ctx, eventSubscriber, cmttypes.EventQueryNewBlock.String(), 100)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to subscribe to new blocks: %w", err)
+	}
+	txs, err = c.client.Subscribe(ctx, eventSubscriber, cmttypes.EventQueryTx.String(), 10000)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to subscribe to txs: %w", err)
+	}
+	return blocks, txs, nil
+}
+
+// Unsubscribe drops all event subscriptions and stops the websocket client.
+func (c *CosmosRPCClient) Unsubscribe(ctx context.Context) {
+	if !c.client.IsRunning() {
+		return
+	}
+	_ = c.client.UnsubscribeAll(ctx, eventSubscriber)
+	_ = c.client.Stop()
+}
+
+// watchEvents records tx inclusion and the latest height from block events until ctx is done.
+// If the node does not accept websocket subscriptions, inclusion is only ever resolved by polling /tx.
+func (c *CosmosModel) watchEvents(ctx context.Context) {
+	blocks, txs, err := c.client.Subscribe(ctx)
+	if err != nil {
+		return
+	}
+	defer c.client.Unsubscribe(context.Background())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-blocks:
+			data, ok := ev.Data.(cmttypes.EventDataNewBlock)
+			if !ok || data.Block == nil {
+				continue
+			}
+			c.onBlock(data.Block, data.ResultFinalizeBlock.TxResults)
+		case ev := <-txs:
+			data, ok := ev.Data.(cmttypes.EventDataTx)
+			if !ok {
+				continue
+			}
+			c.mu.Lock()
+			c.included[TxHash(data.Tx)] = &TxResult{
+				TxResult: data.Result,
+				Height:   data.Height,
+				Index:    data.Index,
+			}
+			c.mu.Unlock()
+		}
+	}
+}
+
+// onBlock records a new block, its txs, their usage and the auction it settled, and forgets Tx events and blocks that are too old to matter.
+func (c *CosmosModel) onBlock(block *cmttypes.Block, results []*abci.ExecTxResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lastBlockEvent = time.Now()
+	if block.Height <= c.latestHeight {
+		return
+	}
+	c.latestHeight = block.Height
+	c.blockTxs[block.Height] = blockTxHashes(block)
+	c.recordUsage(blockUsage(block, results))
+	if result, ok := blockAuction(block); ok {
+		c.recordAuction(result)
+	}
+	for hash, result := range c.included {
+		if result.Height <= c.latestHeight-includedRetention {
+			delete(c.included, hash)
+		}
+	}
+	c.pruneBlockTxs(c.latestHeight)
+}
+
+// eventsLive reports whether block events are currently arriving.
+func (c *CosmosModel) eventsLive() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !c.lastBlockEvent.IsZero() && time.Since(c.lastBlockEvent) < eventsStaleAfter
+}
+
+// includedResult returns the result of a tx seen in a Tx event, if any.
+func (c *CosmosModel) includedResult(hash string) *TxResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.included[hash]
+}
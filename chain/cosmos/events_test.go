@@ -0,0 +1,28 @@
+package cosmos
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnBlockPrunesIncluded(t *testing.T) {
+	c, err := NewCosmosModel(nil, "test", 0, Options{})
+	require.NoError(t, err)
+	c.included["OLD"] = &TxResult{TxResult: abci.ExecTxResult{}, Height: 1}
+	c.included["NEW"] = &TxResult{TxResult: abci.ExecTxResult{Code: 5}, Height: 150, Index: 2}
+	require.False(t, c.eventsLive())
+
+	c.onBlock(&cmttypes.Block{Header: cmttypes.Header{Height: 150}}, nil)
+	require.True(t, c.eventsLive())
+	require.Nil(t, c.includedResult("OLD"))
+
+	tx := &CosmosTransaction{}
+	tx.applyResult(c.includedResult("NEW"))
+	require.Equal(t, StatusTypeFailed, tx.Status)
+	require.Equal(t, int64(150), tx.HeightCompleted)
+	require.Equal(t, uint32(2), tx.IndexCompleted)
+	require.Equal(t, uint32(5), tx.Result.Code)
+}
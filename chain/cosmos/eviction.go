@@ -0,0 +1,118 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// maxEvictionScanBlocks is the most blocks scanned to prove a tx was never included.
+const maxEvictionScanBlocks = 50
+
+// EvictionReason is why a tx left the mempool without being included.
+type EvictionReason string
+
+const (
+	// EvictionRecheck is a tx dropped when the mempool rechecked it after a block, e.g. its sequence was used.
+	EvictionRecheck EvictionReason = "recheck"
+	// EvictionTTL is a tx that outlived the node's ttl-num-blocks or ttl-duration.
+	EvictionTTL EvictionReason = "ttl"
+	// EvictionMempoolFull is a tx dropped between blocks, which only happens when the mempool makes room for others.
+	EvictionMempoolFull EvictionReason = "mempool full"
+)
+
+// classifyEviction guesses why a tx that is provably absent from the blocks produced while it was pending was evicted.
+// CometBFT only rechecks and expires txs when a block is committed, so a tx that vanished without a new block
+// was pushed out by the mempool. ttlBlocks and ttlDuration mirror the node's mempool config, zero if unknown.
+func classifyEviction(tx *CosmosTransaction, ttlBlocks int64, ttlDuration time.Duration) EvictionReason {
+	if tx.VanishedHeight == tx.LastSeenHeight {
+		return EvictionMempoolFull
+	}
+	if ttlBlocks > 0 && tx.VanishedHeight-tx.FirstSeenHeight >= ttlBlocks {
+		return EvictionTTL
+	}
+	if ttlDuration > 0 && tx.TimeCompleted.Sub(tx.FirstSeen) >= ttlDuration {
+		return EvictionTTL
+	}
+	return EvictionRecheck
+}
+
+// currentHeight returns the latest block height, from block events if they are live and from /status otherwise.
+// It returns 0 if the height is unknown.
+func (c *CosmosModel) currentHeight(ctx context.Context) int64 {
+	if c.eventsLive() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.latestHeight
+	}
+	height, err := c.client.LatestHeight(ctx)
+	if err != nil {
+		return 0
+	}
+	return height
+}
+
+// blockHashes returns the tx hashes of the block at height, from block events or the node.
+func (c *CosmosModel) blockHashes(ctx context.Context, height int64) ([]string, error) {
+	c.mu.Lock()
+	hashes, ok := c.blockTxs[height]
+	c.mu.Unlock()
+	if ok {
+		return hashes, nil
+	}
+
+	hashes, err := c.client.BlockTxHashes(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.blockTxs[height] = hashes
+	c.mu.Unlock()
+	return hashes, nil
+}
+
+// pruneBlockTxs forgets the txs of blocks older than includedRetention below height. c.mu must be held.
+func (c *CosmosModel) pruneBlockTxs(height int64) {
+	for h := range c.blockTxs {
+		if h <= height-includedRetention {
+			delete(c.blockTxs, h)
+		}
+	}
+}
+
+// resolveVanished settles a tx that left the mempool but was not found by /tx by scanning the blocks produced while it was pending.
+// A tx found in one of them was included without being indexed. A tx absent from all of them was evicted.
+// Status stays unknown if the pending window is unknown, too long, or a block can't be fetched.
+func (c *CosmosModel) resolveVanished(ctx context.Context, tx *CosmosTransaction) {
+	tx.Status = StatusTypeUnknown
+	if tx.FirstSeenHeight == 0 || tx.VanishedHeight < tx.FirstSeenHeight ||
+		tx.VanishedHeight-tx.FirstSeenHeight >= maxEvictionScanBlocks {
+		return
+	}
+
+	for height := tx.FirstSeenHeight; height <= tx.VanishedHeight; height++ {
+		hashes, err := c.blockHashes(ctx, height)
+		if err != nil {
+			return
+		}
+		index := slices.Index(hashes, tx.Hash)
+		if index == -1 {
+			continue
+		}
+		tx.HeightCompleted = height
+		tx.IndexCompleted = uint32(index)
+		if result, err := c.client.BlockTxResult(ctx, height, uint32(index)); err == nil {
+			tx.applyResult(result)
+		}
+		return
+	}
+
+	tx.Status = StatusTypeEvicted
+	tx.EvictionReason = classifyEviction(tx, c.ttlBlocks, c.ttlDuration)
+}
+
+// evictionDetail describes why a tx was evicted, for the detail box.
+func evictionDetail(tx *CosmosTransaction) string {
+	return fmt.Sprintf("evicted (%s): absent from blocks %d-%d", tx.EvictionReason, tx.FirstSeenHeight, tx.VanishedHeight)
+}
@@ -0,0 +1,50 @@
+package cosmos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyEviction(t *testing.T) {
+	start := time.Now()
+	tx := &CosmosTransaction{FirstSeen: start, FirstSeenHeight: 10, LastSeenHeight: 12, VanishedHeight: 12}
+	require.Equal(t, EvictionMempoolFull, classifyEviction(tx, 0, 0))
+
+	tx.VanishedHeight = 13
+	require.Equal(t, EvictionRecheck, classifyEviction(tx, 0, 0))
+	require.Equal(t, EvictionTTL, classifyEviction(tx, 3, 0))
+	require.Equal(t, EvictionRecheck, classifyEviction(tx, 4, 0))
+
+	tx.TimeCompleted = start.Add(time.Minute)
+	require.Equal(t, EvictionTTL, classifyEviction(tx, 0, time.Minute))
+}
+
+func TestResolveVanished(t *testing.T) {
+	c, err := NewCosmosModel(nil, "test", 0, Options{})
+	require.NoError(t, err)
+	c.blockTxs[10] = []string{"A"}
+	c.blockTxs[11] = nil
+	c.blockTxs[12] = []string{"B", "C"}
+
+	tx := &CosmosTransaction{Hash: "D", FirstSeenHeight: 10, LastSeenHeight: 11, VanishedHeight: 12}
+	c.resolveVanished(context.Background(), tx)
+	require.Equal(t, StatusTypeEvicted, tx.Status)
+	require.Equal(t, EvictionRecheck, tx.EvictionReason)
+
+	// unknown window
+	tx = &CosmosTransaction{Hash: "D", VanishedHeight: 12}
+	c.resolveVanished(context.Background(), tx)
+	require.Equal(t, StatusTypeUnknown, tx.Status)
+
+	// window too long to scan
+	tx = &CosmosTransaction{Hash: "D", FirstSeenHeight: 10, VanishedHeight: 10 + maxEvictionScanBlocks}
+	c.resolveVanished(context.Background(), tx)
+	require.Equal(t, StatusTypeUnknown, tx.Status)
+
+	c.pruneBlockTxs(10 + includedRetention)
+	require.NotContains(t, c.blockTxs, int64(10))
+	require.Contains(t, c.blockTxs, int64(11))
+}
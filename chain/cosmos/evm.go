@@ -0,0 +1,303 @@
+package cosmos
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// EthTx is the Ethereum tx wrapped by a MsgEthereumTx of an Ethermint or Cosmos EVM chain.
+type EthTx struct {
+	Tx *ethtypes.Transaction
+	// From is the sender set on the message, or recovered from the signature. It is the zero address if neither worked.
+	From common.Address
+}
+
+// isMsgEthereumTx matches the MsgEthereumTx of Ethermint (/ethermint.evm.v1), Evmos and Cosmos EVM (/cosmos.evm.vm.v1).
+func isMsgEthereumTx(typeURL string) bool {
+	return strings.HasSuffix(typeURL, ".MsgEthereumTx")
+}
+
+// unwrapEthTx returns the Ethereum tx of the first MsgEthereumTx of a tx, or nil if it has none that decodes.
+// The messages are decoded by hand so xray does not depend on every EVM module's Go types.
+func unwrapEthTx(tx *tx.Tx) *EthTx {
+	if tx == nil || tx.Body == nil {
+		return nil
+	}
+	for _, msg := range tx.Body.Messages {
+		if !isMsgEthereumTx(msg.TypeUrl) {
+			continue
+		}
+		if ethTx, err := decodeMsgEthereumTx(msg.Value); err == nil {
+			return ethTx
+		}
+	}
+	return nil
+}
+
+// protoMessage holds the fields of a protobuf message by number, decoded without its schema.
+type protoMessage struct {
+	varints map[protowire.Number]uint64
+	bytes   map[protowire.Number][][]byte
+}
+
+func parseProto(b []byte) (protoMessage, error) {
+	m := protoMessage{varints: make(map[protowire.Number]uint64), bytes: make(map[protowire.Number][][]byte)}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return m, protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch typ {
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return m, protowire.ParseError(n)
+			}
+			m.varints[num] = v
+			b = b[n:]
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return m, protowire.ParseError(n)
+			}
+			m.bytes[num] = append(m.bytes[num], v)
+			b = b[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return m, protowire.ParseError(n)
+			}
+			b = b[n:]
+		}
+	}
+	return m, nil
+}
+
+func (m protoMessage) field(num protowire.Number) []byte {
+	if values := m.bytes[num]; len(values) > 0 {
+		return values[0]
+	}
+	return nil
+}
+
+func (m protoMessage) str(num protowire.Number) string {
+	return string(m.field(num))
+}
+
+// bigInt decodes a decimal sdk.Int field, zero if unset.
+func (m protoMessage) bigInt(num protowire.Number) (*big.Int, error) {
+	s := m.str(num)
+	if s == "" {
+		return new(big.Int), nil
+	}
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q in field %d", s, num)
+	}
+	return v, nil
+}
+
+func (m protoMessage) signature(v, r, s protowire.Number) (*big.Int, *big.Int, *big.Int) {
+	return new(big.Int).SetBytes(m.field(v)), new(big.Int).SetBytes(m.field(r)), new(big.Int).SetBytes(m.field(s))
+}
+
+func (m protoMessage) to(num protowire.Number) *common.Address {
+	s := m.str(num)
+	if s == "" {
+		// contract creation
+		return nil
+	}
+	addr := common.HexToAddress(s)
+	return &addr
+}
+
+// accessList decodes repeated AccessTuple{address = 1, storage_keys = 2} fields.
+func (m protoMessage) accessList(num protowire.Number) (ethtypes.AccessList, error) {
+	var list ethtypes.AccessList
+	for _, bz := range m.bytes[num] {
+		tuple, err := parseProto(bz)
+		if err != nil {
+			return nil, err
+		}
+		entry := ethtypes.AccessTuple{Address: common.HexToAddress(tuple.str(1))}
+		for _, key := range tuple.bytes[2] {
+			entry.StorageKeys = append(entry.StorageKeys, common.HexToHash(string(key)))
+		}
+		list = append(list, entry)
+	}
+	return list, nil
+}
+
+// decodeMsgEthereumTx decodes a MsgEthereumTx. Cosmos EVM carries the signed tx in its binary encoding (raw = 6)
+// and the sender as bytes (from = 5). Ethermint and Evmos carry it as an Any of LegacyTx, AccessListTx or
+// DynamicFeeTx (data = 1) and the sender as a hex string (from = 4).
+func decodeMsgEthereumTx(value []byte) (*EthTx, error) {
+	msg, err := parseProto(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var ethTx *ethtypes.Transaction
+	if raw := msg.field(6); len(raw) > 0 {
+		ethTx = new(ethtypes.Transaction)
+		if err := ethTx.UnmarshalBinary(raw); err != nil {
+			return nil, err
+		}
+	} else {
+		data, err := parseProto(msg.field(1))
+		if err != nil {
+			return nil, err
+		}
+		if ethTx, err = decodeEthermintTx(data.str(1), data.field(2)); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &EthTx{Tx: ethTx}
+	switch {
+	case len(msg.field(5)) == common.AddressLength:
+		result.From = common.BytesToAddress(msg.field(5))
+	case common.IsHexAddress(msg.str(4)):
+		result.From = common.HexToAddress(msg.str(4))
+	default:
+		signer := ethtypes.LatestSignerForChainID(ethTx.ChainId())
+		if !ethTx.Protected() {
+			signer = ethtypes.HomesteadSigner{}
+		}
+		result.From, _ = ethtypes.Sender(signer, ethTx)
+	}
+	return result, nil
+}
+
+// decodeEthermintTx decodes the Ethermint tx data types, which spell out every field of the Ethereum tx.
+func decodeEthermintTx(typeURL string, value []byte) (*ethtypes.Transaction, error) {
+	m, err := parseProto(value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case strings.HasSuffix(typeURL, ".LegacyTx"):
+		gasPrice, err := m.bigInt(2)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := m.bigInt(5)
+		if err != nil {
+			return nil, err
+		}
+		v, r, s := m.signature(7, 8, 9)
+		return ethtypes.NewTx(&ethtypes.LegacyTx{
+			Nonce: m.varints[1], GasPrice: gasPrice, Gas: m.varints[3], To: m.to(4), Value: amount, Data: m.field(6),
+			V: v, R: r, S: s,
+		}), nil
+	case strings.HasSuffix(typeURL, ".AccessListTx"):
+		chainID, err := m.bigInt(1)
+		if err != nil {
+			return nil, err
+		}
+		gasPrice, err := m.bigInt(3)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := m.bigInt(6)
+		if err != nil {
+			return nil, err
+		}
+		accesses, err := m.accessList(8)
+		if err != nil {
+			return nil, err
+		}
+		v, r, s := m.signature(9, 10, 11)
+		return ethtypes.NewTx(&ethtypes.AccessListTx{
+			ChainID: chainID, Nonce: m.varints[2], GasPrice: gasPrice, Gas: m.varints[4], To: m.to(5), Value: amount,
+			Data: m.field(7), AccessList: accesses, V: v, R: r, S: s,
+		}), nil
+	case strings.HasSuffix(typeURL, ".DynamicFeeTx"):
+		chainID, err := m.bigInt(1)
+		if err != nil {
+			return nil, err
+		}
+		tipCap, err := m.bigInt(3)
+		if err != nil {
+			return nil, err
+		}
+		feeCap, err := m.bigInt(4)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := m.bigInt(7)
+		if err != nil {
+			return nil, err
+		}
+		accesses, err := m.accessList(9)
+		if err != nil {
+			return nil, err
+		}
+		v, r, s := m.signature(10, 11, 12)
+		return ethtypes.NewTx(&ethtypes.DynamicFeeTx{
+			ChainID: chainID, Nonce: m.varints[2], GasTipCap: tipCap, GasFeeCap: feeCap, Gas: m.varints[5], To: m.to(6),
+			Value: amount, Data: m.field(8), AccessList: accesses, V: v, R: r, S: s,
+		}), nil
+	}
+	return nil, fmt.Errorf("unknown Ethereum tx data type %s", typeURL)
+}
+
+// shortenEthHash keeps the start and the end of an Ethereum hash or address.
+func shortenEthHash(hex string) string {
+	if len(hex) <= 12 {
+		return hex
+	}
+	return hex[:6] + ".." + hex[len(hex)-4:]
+}
+
+// formatGwei formats a wei amount in gwei.
+func formatGwei(wei *big.Int) string {
+	gwei, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.GWei)).Float64()
+	if gwei >= 1000000 {
+		return fmt.Sprintf("%.1fMgw", gwei/1000000)
+	} else if gwei >= 1000 {
+		return fmt.Sprintf("%.1fKgw", gwei/1000)
+	}
+	return fmt.Sprintf("%.2fgw", gwei)
+}
+
+// formatEthTo formats the recipient of an Ethereum tx, "create" for contract creations.
+func formatEthTo(tx *ethtypes.Transaction) string {
+	if tx.To() == nil {
+		return "create"
+	}
+	return shortenEthHash(tx.To().Hex())
+}
+
+// ethLine renders the eth hash, sender, recipient, nonce and fee cap of a wrapped Ethereum tx, like the eth panels do,
+// truncated to the box width.
+func ethLine(eth *EthTx) string {
+	return truncate(fmt.Sprintf("%s | %s→%s | %d | %s",
+		shortenEthHash(eth.Tx.Hash().Hex()), shortenEthHash(eth.From.Hex()), formatEthTo(eth.Tx), eth.Tx.Nonce(), formatGwei(eth.Tx.GasFeeCap())), lineWidth)
+}
+
+// ethDetailLines renders a wrapped Ethereum tx for the detail box.
+func ethDetailLines(eth *EthTx, cosmosHash string) []string {
+	to := "create"
+	if eth.Tx.To() != nil {
+		to = eth.Tx.To().Hex()
+	}
+	return []string{
+		inMempoolStyleCosmos.Render("MsgEthereumTx"),
+		fmt.Sprintf("eth %s", eth.Tx.Hash().Hex()),
+		fmt.Sprintf("cosmos %s", truncateHash(cosmosHash)),
+		fmt.Sprintf("from %s", eth.From.Hex()),
+		fmt.Sprintf("to %s", to),
+		fmt.Sprintf("nonce %d | gas %s | fee cap %s | tip %s",
+			eth.Tx.Nonce(), formatGas(eth.Tx.Gas()), formatGwei(eth.Tx.GasFeeCap()), formatGwei(eth.Tx.GasTipCap())),
+	}
+}
@@ -0,0 +1,115 @@
+package cosmos
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func signedEthTx(t *testing.T) (*ethtypes.Transaction, common.Address) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	signed, err := ethtypes.SignNewTx(key, ethtypes.LatestSignerForChainID(big.NewInt(9001)), &ethtypes.DynamicFeeTx{
+		ChainID:   big.NewInt(9001),
+		Nonce:     7,
+		GasTipCap: big.NewInt(1e9),
+		GasFeeCap: big.NewInt(30e9),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1e18),
+	})
+	require.NoError(t, err)
+	return signed, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+// ethermintMsg encodes a signed dynamic fee tx as an Ethermint MsgEthereumTx without its from field.
+func ethermintMsg(signed *ethtypes.Transaction) []byte {
+	v, r, s := signed.RawSignatureValues()
+	var data []byte
+	data = appendString(data, 1, signed.ChainId().String())
+	data = appendVarint(data, 2, signed.Nonce())
+	data = appendString(data, 3, signed.GasTipCap().String())
+	data = appendString(data, 4, signed.GasFeeCap().String())
+	data = appendVarint(data, 5, signed.Gas())
+	data = appendString(data, 6, signed.To().Hex())
+	data = appendString(data, 7, signed.Value().String())
+	data = appendBytes(data, 10, v.Bytes())
+	data = appendBytes(data, 11, r.Bytes())
+	data = appendBytes(data, 12, s.Bytes())
+
+	var wrapper []byte
+	wrapper = appendString(wrapper, 1, "/ethermint.evm.v1.DynamicFeeTx")
+	wrapper = appendBytes(wrapper, 2, data)
+
+	var msg []byte
+	msg = appendBytes(msg, 1, wrapper)
+	msg = appendString(msg, 3, signed.Hash().Hex())
+	return msg
+}
+
+func TestDecodeMsgEthereumTx(t *testing.T) {
+	signed, from := signedEthTx(t)
+
+	// Ethermint, sender recovered from the signature
+	eth, err := decodeMsgEthereumTx(ethermintMsg(signed))
+	require.NoError(t, err)
+	require.Equal(t, signed.Hash(), eth.Tx.Hash())
+	require.Equal(t, from, eth.From)
+	require.Equal(t, uint64(7), eth.Tx.Nonce())
+
+	// Cosmos EVM, raw tx and sender bytes
+	raw, err := signed.MarshalBinary()
+	require.NoError(t, err)
+	var msg []byte
+	msg = appendBytes(msg, 5, from.Bytes())
+	msg = appendBytes(msg, 6, raw)
+	eth, err = decodeMsgEthereumTx(msg)
+	require.NoError(t, err)
+	require.Equal(t, signed.Hash(), eth.Tx.Hash())
+	require.Equal(t, from, eth.From)
+
+	_, err = decodeMsgEthereumTx([]byte{0xff})
+	require.Error(t, err)
+}
+
+func TestUnwrapEthTx(t *testing.T) {
+	signed, from := signedEthTx(t)
+	wrapped := &tx.Tx{Body: &tx.TxBody{Messages: []*codectypes.Any{
+		{TypeUrl: "/ethermint.evm.v1.MsgEthereumTx", Value: ethermintMsg(signed)},
+	}}}
+
+	eth := unwrapEthTx(wrapped)
+	require.NotNil(t, eth)
+	require.Equal(t, signed.Hash(), eth.Tx.Hash())
+	require.Equal(t, shortenEthHash(signed.Hash().Hex())+" | "+shortenEthHash(from.Hex())+"→0x0000..00AA | 7 | 30.00gw", ethLine(eth))
+
+	require.Nil(t, unwrapEthTx(&tx.Tx{Body: &tx.TxBody{}}))
+
+	to := common.HexToAddress("0xBB")
+	busy := &EthTx{Tx: ethtypes.NewTx(&ethtypes.DynamicFeeTx{Nonce: 1234, GasFeeCap: big.NewInt(25_000_000_000), To: &to}), From: from}
+	require.LessOrEqual(t, lipgloss.Width(ethLine(busy)), lineWidth)
+}
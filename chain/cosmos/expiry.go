@@ -0,0 +1,106 @@
+package cosmos
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+// DefaultExpiryWarnBlocks is how many blocks before its timeout a tx is flagged as expiring when unset.
+const DefaultExpiryWarnBlocks = 5
+
+// Expiry is when a tx stops being valid.
+type Expiry struct {
+	// Height is the last height the tx can be included at, 0 if unset.
+	Height uint64
+	// Timestamp is the last block time the tx can be included at, zero if unset.
+	Timestamp time.Time
+	// Unordered txs skip sequence checks and must set a timeout timestamp.
+	Unordered bool
+}
+
+// ExpiryState is how close a pending tx is to its timeout.
+type ExpiryState int
+
+const (
+	ExpiryNone ExpiryState = iota
+	ExpirySoon
+	ExpiryPassed
+)
+
+// txExpiry returns the timeouts of a tx. The timeout timestamp and unordered flag were added to TxBody after
+// SDK v0.50 (fields 5 and 4) and are dropped when decoding, so they are read from the raw bytes.
+func txExpiry(raw []byte, decoded *tx.Tx) Expiry {
+	var expiry Expiry
+	if decoded != nil && decoded.Body != nil {
+		expiry.Height = decoded.Body.TimeoutHeight
+	}
+
+	txMsg, err := parseProto(raw)
+	if err != nil {
+		return expiry
+	}
+	body, err := parseProto(txMsg.field(1))
+	if err != nil {
+		return expiry
+	}
+	expiry.Unordered = body.varints[4] != 0
+	if ts := body.field(5); ts != nil {
+		timestamp, err := parseProto(ts)
+		if err == nil {
+			expiry.Timestamp = time.Unix(int64(timestamp.varints[1]), int64(int32(timestamp.varints[2])))
+		}
+	}
+	return expiry
+}
+
+// state returns how close the expiry is at height and time now, warning within warnBlocks blocks of it.
+// A timeout timestamp is converted to blocks with blockInterval, and only passes once it does if that is unknown.
+func (e Expiry) state(height int64, now time.Time, warnBlocks int64, blockInterval time.Duration) ExpiryState {
+	state := ExpiryNone
+	if e.Height > 0 && height > 0 {
+		switch {
+		case uint64(height) >= e.Height:
+			// the next block is past the timeout height
+			return ExpiryPassed
+		case uint64(height)+uint64(warnBlocks) >= e.Height:
+			state = ExpirySoon
+		}
+	}
+	if !e.Timestamp.IsZero() {
+		switch {
+		case !now.Before(e.Timestamp):
+			return ExpiryPassed
+		case blockInterval > 0 && e.Timestamp.Sub(now) <= time.Duration(warnBlocks)*blockInterval:
+			state = ExpirySoon
+		}
+	}
+	return state
+}
+
+// expiredAt reports whether the tx could no longer be included once it left the mempool.
+func (e Expiry) expiredAt(height int64, at time.Time) bool {
+	if e.Height > 0 && height > 0 && uint64(height) > e.Height {
+		return true
+	}
+	return !e.Timestamp.IsZero() && at.After(e.Timestamp)
+}
+
+// formatExpiry describes the timeouts of a tx for the detail box.
+func formatExpiry(e Expiry) string {
+	var s string
+	if e.Height > 0 {
+		s = fmt.Sprintf("timeout height %d", e.Height)
+	}
+	if !e.Timestamp.IsZero() {
+		if s != "" {
+			s += ", "
+		}
+		s += "timeout " + e.Timestamp.UTC().Format(time.DateTime)
+	}
+	if e.Unordered {
+		s += " (unordered)"
+	}
+	return s
+}
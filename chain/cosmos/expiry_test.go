@@ -0,0 +1,55 @@
+package cosmos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestTxExpiry(t *testing.T) {
+	// a TxBody of a newer SDK, with unordered = 4 and timeout_timestamp = 5
+	var timestamp []byte
+	timestamp = appendVarint(timestamp, 1, 1700000000)
+	timestamp = appendVarint(timestamp, 2, 500)
+	var body []byte
+	body = appendVarint(body, 3, 120)
+	body = appendVarint(body, 4, 1)
+	body = appendBytes(body, 5, timestamp)
+	raw := appendBytes(nil, 1, body)
+
+	var decoded tx.Tx
+	require.NoError(t, decoded.Unmarshal(raw))
+	expiry := txExpiry(raw, &decoded)
+	require.Equal(t, uint64(120), expiry.Height)
+	require.True(t, expiry.Unordered)
+	require.Equal(t, time.Unix(1700000000, 500), expiry.Timestamp)
+
+	require.Equal(t, Expiry{}, txExpiry([]byte{byte(protowire.BytesType)}, nil))
+}
+
+func TestExpiryState(t *testing.T) {
+	now := time.Now()
+	byHeight := Expiry{Height: 100}
+	require.Equal(t, ExpiryNone, byHeight.state(90, now, 5, 0))
+	require.Equal(t, ExpirySoon, byHeight.state(95, now, 5, 0))
+	require.Equal(t, ExpiryPassed, byHeight.state(100, now, 5, 0))
+	require.Equal(t, ExpiryNone, byHeight.state(0, now, 5, 0))
+	require.False(t, byHeight.expiredAt(100, now))
+	require.True(t, byHeight.expiredAt(101, now))
+
+	byTime := Expiry{Timestamp: now.Add(time.Minute)}
+	require.Equal(t, ExpiryNone, byTime.state(0, now, 5, 0))
+	require.Equal(t, ExpiryNone, byTime.state(0, now, 5, 6*time.Second))
+	require.Equal(t, ExpirySoon, byTime.state(0, now, 5, 12*time.Second))
+	require.Equal(t, ExpiryPassed, byTime.state(0, now.Add(time.Minute), 5, 0))
+	require.True(t, byTime.expiredAt(0, now.Add(2*time.Minute)))
+}
+
+func TestUnorderedSkipsSequences(t *testing.T) {
+	unordered := signedTx("U", "alice", 0)
+	unordered.Expiry.Unordered = true
+	require.Nil(t, signerSequences(unordered))
+}
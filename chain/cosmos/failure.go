@@ -0,0 +1,116 @@
+package cosmos
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+	"github.com/cometbft/cometbft/abci/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// failureExplanations says in plain words what common tx errors mean and what to do about them.
+var failureExplanations = []struct {
+	err         *errorsmod.Error
+	explanation string
+}{
+	{sdkerrors.ErrOutOfGas, "ran out of gas, resubmit with a higher gas limit"},
+	{sdkerrors.ErrInsufficientFee, "fee is below the validator's minimum gas price"},
+	{sdkerrors.ErrWrongSequence, "account sequence mismatch, another tx used this sequence first"},
+	{sdkerrors.ErrInvalidSequence, "account sequence mismatch, another tx used this sequence first"},
+	{sdkerrors.ErrUnauthorized, "signer is not allowed to execute a message, or the signature is invalid"},
+	{sdkerrors.ErrInsufficientFunds, "balance is too low for the fee or the amount sent"},
+	{sdkerrors.ErrTxTimeoutHeight, "timeout height passed before the tx was included"},
+	{sdkerrors.ErrInvalidCoins, "amount or denom is invalid"},
+	{sdkerrors.ErrInvalidAddress, "an address in a message is invalid"},
+	{sdkerrors.ErrNotFound, "a referenced object does not exist"},
+}
+
+// Failure describes why an included tx failed.
+type Failure struct {
+	Code      uint32
+	Codespace string
+	// Description is the registered description of the error, "unknown" for modules this codec does not import.
+	Description string
+	// Explanation is set for common errors.
+	Explanation string
+	Log         string
+	GasUsed     int64
+	GasWanted   int64
+	Events      []types.Event
+}
+
+// describeFailure returns the failure of a tx result, or nil if the tx succeeded.
+func describeFailure(result *types.ExecTxResult) *Failure {
+	if result == nil || result.Code == types.CodeTypeOK {
+		return nil
+	}
+	failure := &Failure{
+		Code:        result.Code,
+		Codespace:   result.Codespace,
+		Description: "unknown",
+		Log:         result.Log,
+		GasUsed:     result.GasUsed,
+		GasWanted:   result.GasWanted,
+		Events:      result.Events,
+	}
+
+	err := errorsmod.ABCIError(result.Codespace, result.Code, "")
+	var registered *errorsmod.Error
+	if errors.As(err, &registered) {
+		failure.Description = registered.Error()
+	}
+	for _, known := range failureExplanations {
+		if errorsmod.IsOf(err, known.err) {
+			failure.Explanation = known.explanation
+			break
+		}
+	}
+	return failure
+}
+
+// formatEvent renders an event as "type key=value key=value".
+func formatEvent(event types.Event) string {
+	parts := []string{event.Type}
+	for _, attr := range event.Attributes {
+		parts = append(parts, attr.Key+"="+attr.Value)
+	}
+	return strings.Join(parts, " ")
+}
+
+// failureLines renders the failure of a tx for the failure box: the error, the gas, the start of the log and the events.
+func failureLines(tx *CosmosTransaction, failure *Failure) []string {
+	const maxLines = 10
+
+	gasWanted := failure.GasWanted
+	if gasWanted == 0 {
+		gasWanted = int64(gasLimit(tx.Tx))
+	}
+
+	lines := []string{
+		fmt.Sprintf("Failure %s | H%d#%d", truncateHash(tx.Hash), tx.HeightCompleted, tx.IndexCompleted),
+		strings.Repeat("-", 50),
+		failedStyleCosmos.Render(truncate(fmt.Sprintf("code %d (%s): %s", failure.Code, failure.Codespace, failure.Description), lineWidth)),
+	}
+	if failure.Explanation != "" {
+		lines = append(lines, wrapLines("→ "+failure.Explanation, lineWidth)...)
+	}
+	lines = append(lines, fmt.Sprintf("gas %s used / %s wanted", formatGas(uint64(failure.GasUsed)), formatGas(uint64(gasWanted))))
+	if failure.Log != "" {
+		// keep room for the events
+		logLines := wrapLines("log: "+failure.Log, lineWidth)
+		lines = append(lines, logLines[:min(len(logLines), 3)]...)
+	}
+	for _, event := range failure.Events {
+		lines = append(lines, fadedStyleCosmos.Render(truncate(formatEvent(event), lineWidth)))
+	}
+
+	if len(lines) > maxLines {
+		lines = append(lines[:maxLines-1], fadedStyleCosmos.Render("…"))
+	}
+	for len(lines) < maxLines {
+		lines = append(lines, "")
+	}
+	return lines
+}
@@ -0,0 +1,56 @@
+package cosmos
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeFailure(t *testing.T) {
+	require.Nil(t, describeFailure(&abci.ExecTxResult{}))
+
+	failure := describeFailure(&abci.ExecTxResult{
+		Code:      11,
+		Codespace: "sdk",
+		Log:       "out of gas in location: WriteFlat; gasWanted: 100000, gasUsed: 100512",
+		GasWanted: 100000,
+		GasUsed:   100512,
+		Events: []abci.Event{{
+			Type:       "tx",
+			Attributes: []abci.EventAttribute{{Key: "fee", Value: "500uatom"}},
+		}},
+	})
+	require.Equal(t, "out of gas", failure.Description)
+	require.Contains(t, failure.Explanation, "higher gas limit")
+	require.Equal(t, "tx fee=500uatom", formatEvent(failure.Events[0]))
+
+	// registered by the bank module, without an explanation
+	failure = describeFailure(&abci.ExecTxResult{Code: 5, Codespace: "bank"})
+	require.Equal(t, "send transactions are disabled", failure.Description)
+	require.Empty(t, failure.Explanation)
+
+	failure = describeFailure(&abci.ExecTxResult{Code: 7, Codespace: "mychain"})
+	require.Equal(t, "unknown", failure.Description)
+
+	lines := failureLines(&CosmosTransaction{Hash: "ABCDEF0123456789", HeightCompleted: 9}, describeFailure(&abci.ExecTxResult{Code: 13, Codespace: "sdk"}))
+	require.Len(t, lines, 10)
+	require.Equal(t, "Failure ABCDEF...6789 | H9#0", lines[0])
+	require.Contains(t, lines[3], "minimum gas price")
+
+	// the longest explanation wraps within the box and is counted against its lines
+	lines = failureLines(&CosmosTransaction{Hash: "ABCDEF0123456789"}, &Failure{
+		Code:        4,
+		Codespace:   "sdk",
+		Description: "unauthorized",
+		Explanation: "signer is not allowed to execute a message, or the signature is invalid",
+		Log:         strings.Repeat("signature verification failed; ", 6),
+		Events:      make([]abci.Event, 5),
+	})
+	require.Len(t, lines, 10)
+	for _, line := range lines {
+		require.LessOrEqual(t, lipgloss.Width(line), lineWidth)
+	}
+}
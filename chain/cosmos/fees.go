@@ -0,0 +1,108 @@
+package cosmos
+
+import (
+	"cmp"
+	"fmt"
+	"math/big"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+// MempoolSort is the order the mempool box lists txs in.
+type MempoolSort string
+
+const (
+	MempoolSortHash     MempoolSort = "hash"
+	MempoolSortGasPrice MempoolSort = "gas_price"
+)
+
+// txFee returns the fee coins of the tx.
+func txFee(tx *tx.Tx) sdk.Coins {
+	if tx == nil || tx.AuthInfo == nil || tx.AuthInfo.Fee == nil {
+		return nil
+	}
+	return tx.AuthInfo.Fee.Amount
+}
+
+// gasLimit returns the gas wanted by the tx.
+func gasLimit(tx *tx.Tx) uint64 {
+	if tx == nil || tx.AuthInfo == nil || tx.AuthInfo.Fee == nil {
+		return 0
+	}
+	return tx.AuthInfo.Fee.GasLimit
+}
+
+// gasPrice returns the effective gas price (fee / gas) of the first fee denom.
+// ok is false for txs without a fee or gas limit.
+func gasPrice(tx *tx.Tx) (price float64, denom string, ok bool) {
+	fee := txFee(tx)
+	gas := gasLimit(tx)
+	if len(fee) == 0 || gas == 0 {
+		return 0, "", false
+	}
+	amount := new(big.Float).SetInt(fee[0].Amount.BigInt())
+	price, _ = amount.Quo(amount, new(big.Float).SetUint64(gas)).Float64()
+	return price, fee[0].Denom, true
+}
+
+// compareGasPrice orders txs by gas price, highest first. Prices of different denoms are compared as-is.
+func compareGasPrice(a, b *CosmosTransaction) int {
+	priceA, _, _ := gasPrice(a.Tx)
+	priceB, _, _ := gasPrice(b.Tx)
+	return cmp.Compare(priceB, priceA)
+}
+
+// formatAmount scales an integer amount like formatGas.
+func formatAmount(amount *big.Int) string {
+	f, _ := new(big.Float).SetInt(amount).Float64()
+	switch {
+	case f >= 1e9:
+		return fmt.Sprintf("%.1fB", f/1e9)
+	case f >= 1e6:
+		return fmt.Sprintf("%.1fM", f/1e6)
+	case f >= 1e3:
+		return fmt.Sprintf("%.1fK", f/1e3)
+	}
+	return amount.String()
+}
+
+// shortDenom keeps long denoms such as ibc/ hashes readable in a table column.
+func shortDenom(denom string) string {
+	if strings.HasPrefix(denom, "ibc/") && len(denom) > 10 {
+		return denom[:8] + "…"
+	}
+	return denom
+}
+
+// formatFee formats the first fee coin, noting how many other denoms were paid.
+func formatFee(tx *tx.Tx) string {
+	fee := txFee(tx)
+	if len(fee) == 0 {
+		return "no fee"
+	}
+	s := formatAmount(fee[0].Amount.BigInt()) + shortDenom(fee[0].Denom)
+	if len(fee) > 1 {
+		s += fmt.Sprintf("+%d", len(fee)-1)
+	}
+	return s
+}
+
+// formatGasPrice formats the effective gas price of a tx.
+func formatGasPrice(tx *tx.Tx) string {
+	price, _, ok := gasPrice(tx)
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("@%.4g", price)
+}
+
+func formatGas(gas uint64) string {
+	if gas >= 1000000 {
+		return fmt.Sprintf("%.1fM", float64(gas)/1000000)
+	} else if gas >= 1000 {
+		return fmt.Sprintf("%.1fK", float64(gas)/1000)
+	}
+	return fmt.Sprintf("%d", gas)
+}
@@ -0,0 +1,31 @@
+package cosmos
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasPrice(t *testing.T) {
+	cheap, _ := testTx(t)
+	cheap.AuthInfo.Fee.Amount = sdk.NewCoins(sdk.NewInt64Coin("uatom", 5000))
+	expensive, _ := testTx(t)
+	expensive.AuthInfo.Fee.Amount = sdk.NewCoins(sdk.NewInt64Coin("uatom", 20000))
+	free, _ := testTx(t)
+
+	price, denom, ok := gasPrice(cheap)
+	require.True(t, ok)
+	require.Equal(t, "uatom", denom)
+	require.InDelta(t, 0.025, price, 1e-12)
+	require.Equal(t, "5.0Kuatom", formatFee(cheap))
+	require.Equal(t, "@0.025", formatGasPrice(cheap))
+
+	_, _, ok = gasPrice(free)
+	require.False(t, ok)
+	require.Equal(t, "no fee", formatFee(free))
+
+	a, b, c := &CosmosTransaction{Tx: cheap}, &CosmosTransaction{Tx: expensive}, &CosmosTransaction{Tx: free}
+	require.Positive(t, compareGasPrice(a, b))
+	require.Negative(t, compareGasPrice(a, c))
+}
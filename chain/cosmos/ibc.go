@@ -0,0 +1,247 @@
+package cosmos
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+)
+
+// ibcRetention is how long relays are remembered after they were first seen.
+const ibcRetention = 10 * time.Minute
+
+// PacketKind is the IBC message that relayed a packet.
+type PacketKind string
+
+const (
+	PacketRecv    PacketKind = "recv"
+	PacketAck     PacketKind = "ack"
+	PacketTimeout PacketKind = "timeout"
+)
+
+// PacketRef identifies a packet relayed to this chain. Port and Channel are the end of the channel on this chain.
+type PacketRef struct {
+	Port     string
+	Channel  string
+	Sequence uint64
+	Kind     PacketKind
+}
+
+// PacketMsg is a packet relayed by a message of a tx.
+type PacketMsg struct {
+	PacketRef
+	Relayer string
+}
+
+// ibcPackets returns the packets relayed by the messages of a tx.
+func ibcPackets(msgs []DecodedMsg) []PacketMsg {
+	var packets []PacketMsg
+	for _, msg := range msgs {
+		switch m := msg.Msg.(type) {
+		case *channeltypes.MsgRecvPacket:
+			packets = append(packets, PacketMsg{
+				PacketRef: PacketRef{m.Packet.DestinationPort, m.Packet.DestinationChannel, m.Packet.Sequence, PacketRecv},
+				Relayer:   m.Signer,
+			})
+		case *channeltypes.MsgAcknowledgement:
+			packets = append(packets, PacketMsg{
+				PacketRef: PacketRef{m.Packet.SourcePort, m.Packet.SourceChannel, m.Packet.Sequence, PacketAck},
+				Relayer:   m.Signer,
+			})
+		case *channeltypes.MsgTimeout:
+			packets = append(packets, PacketMsg{
+				PacketRef: PacketRef{m.Packet.SourcePort, m.Packet.SourceChannel, m.Packet.Sequence, PacketTimeout},
+				Relayer:   m.Signer,
+			})
+		case *channeltypes.MsgTimeoutOnClose:
+			packets = append(packets, PacketMsg{
+				PacketRef: PacketRef{m.Packet.SourcePort, m.Packet.SourceChannel, m.Packet.Sequence, PacketTimeout},
+				Relayer:   m.Signer,
+			})
+		}
+	}
+	return packets
+}
+
+// formatPackets summarizes the packets of a tx for the message type column, e.g. "ibc recv×3".
+func formatPackets(packets []PacketMsg) string {
+	counts := make(map[PacketKind]int)
+	for _, packet := range packets {
+		counts[packet.Kind]++
+	}
+	var parts []string
+	for _, kind := range []PacketKind{PacketRecv, PacketAck, PacketTimeout} {
+		if counts[kind] > 0 {
+			parts = append(parts, fmt.Sprintf("%s×%d", kind, counts[kind]))
+		}
+	}
+	return "ibc " + strings.Join(parts, " ")
+}
+
+// relay is a tx that relayed a packet.
+type relay struct {
+	tx      *CosmosTransaction
+	relayer string
+}
+
+// ibcTracker remembers which txs relayed which packets, to find packets relayed more than once.
+type ibcTracker struct {
+	relays    map[PacketRef][]relay
+	firstSeen map[PacketRef]time.Time
+}
+
+func newIBCTracker() *ibcTracker {
+	return &ibcTracker{
+		relays:    make(map[PacketRef][]relay),
+		firstSeen: make(map[PacketRef]time.Time),
+	}
+}
+
+// observe records the packets relayed by a tx seen for the first time.
+func (t *ibcTracker) observe(tx *CosmosTransaction) {
+	for _, packet := range tx.Packets {
+		if _, ok := t.firstSeen[packet.PacketRef]; !ok {
+			t.firstSeen[packet.PacketRef] = tx.FirstSeen
+		}
+		t.relays[packet.PacketRef] = append(t.relays[packet.PacketRef], relay{tx: tx, relayer: packet.Relayer})
+	}
+}
+
+// prune forgets packets first seen more than ibcRetention before now.
+func (t *ibcTracker) prune(now time.Time) {
+	for ref, seen := range t.firstSeen {
+		if now.Sub(seen) > ibcRetention {
+			delete(t.firstSeen, ref)
+			delete(t.relays, ref)
+		}
+	}
+}
+
+// ChannelSummary is the relaying activity on a channel.
+type ChannelSummary struct {
+	Port    string
+	Channel string
+	Packets map[PacketKind]int
+	// LastSequence is the highest packet sequence relayed.
+	LastSequence uint64
+	Relayers     int
+	// Redundant counts packets relayed by more than one tx.
+	Redundant int
+}
+
+// RelayerSummary is the activity of a relayer.
+type RelayerSummary struct {
+	Address string
+	Packets int
+	// Redundant counts the relayer's packets that other txs relayed too, Failed the relays of it that failed.
+	Redundant int
+	Failed    int
+}
+
+// summarize groups the remembered relays by channel and by relayer, busiest first.
+func (t *ibcTracker) summarize() ([]ChannelSummary, []RelayerSummary) {
+	type channelKey struct{ port, channel string }
+	channels := make(map[channelKey]*ChannelSummary)
+	channelRelayers := make(map[channelKey]map[string]bool)
+	relayers := make(map[string]*RelayerSummary)
+
+	for ref, relays := range t.relays {
+		key := channelKey{ref.Port, ref.Channel}
+		channel, ok := channels[key]
+		if !ok {
+			channel = &ChannelSummary{Port: ref.Port, Channel: ref.Channel, Packets: make(map[PacketKind]int)}
+			channels[key] = channel
+			channelRelayers[key] = make(map[string]bool)
+		}
+		channel.Packets[ref.Kind]++
+		channel.LastSequence = max(channel.LastSequence, ref.Sequence)
+
+		txs := make(map[string]bool)
+		for _, r := range relays {
+			txs[r.tx.Hash] = true
+		}
+		redundant := len(txs) > 1
+		if redundant {
+			channel.Redundant++
+		}
+
+		counted := make(map[string]bool)
+		for _, r := range relays {
+			channelRelayers[key][r.relayer] = true
+			relayer, ok := relayers[r.relayer]
+			if !ok {
+				relayer = &RelayerSummary{Address: r.relayer}
+				relayers[r.relayer] = relayer
+			}
+			if r.tx.Status == StatusTypeFailed {
+				relayer.Failed++
+			}
+			if counted[r.relayer] {
+				continue
+			}
+			counted[r.relayer] = true
+			relayer.Packets++
+			if redundant {
+				relayer.Redundant++
+			}
+		}
+	}
+
+	channelSummaries := make([]ChannelSummary, 0, len(channels))
+	for key, channel := range channels {
+		channel.Relayers = len(channelRelayers[key])
+		channelSummaries = append(channelSummaries, *channel)
+	}
+	slices.SortFunc(channelSummaries, func(a, b ChannelSummary) int {
+		if order := cmp.Compare(totalPackets(b), totalPackets(a)); order != 0 {
+			return order
+		}
+		return strings.Compare(a.Port+a.Channel, b.Port+b.Channel)
+	})
+
+	relayerSummaries := make([]RelayerSummary, 0, len(relayers))
+	for _, relayer := range relayers {
+		relayerSummaries = append(relayerSummaries, *relayer)
+	}
+	slices.SortFunc(relayerSummaries, func(a, b RelayerSummary) int {
+		if order := cmp.Compare(b.Packets, a.Packets); order != 0 {
+			return order
+		}
+		return strings.Compare(a.Address, b.Address)
+	})
+	return channelSummaries, relayerSummaries
+}
+
+func totalPackets(channel ChannelSummary) int {
+	var total int
+	for _, n := range channel.Packets {
+		total += n
+	}
+	return total
+}
+
+// channelLine renders a channel summary as a row of the IBC box, with the packet counts by kind compacted to their
+// initial, e.g. "45r/38a/2t".
+func channelLine(channel ChannelSummary) string {
+	var counts []string
+	for _, kind := range []PacketKind{PacketRecv, PacketAck, PacketTimeout} {
+		if n := channel.Packets[kind]; n > 0 {
+			counts = append(counts, fmt.Sprintf("%d%s", n, string(kind)[:1]))
+		}
+	}
+	name := truncate(channel.Port, 10) + "/" + strings.Replace(channel.Channel, "channel-", "ch-", 1)
+	line := fmt.Sprintf("%s | %s | #%d | %d rly", name, strings.Join(counts, "/"), channel.LastSequence, channel.Relayers)
+	if channel.Redundant > 0 {
+		line += fmt.Sprintf(" | %d dup", channel.Redundant)
+	}
+	return truncate(line, lineWidth)
+}
+
+// relayerLine renders a relayer summary as a row of the IBC box.
+func relayerLine(relayer RelayerSummary) string {
+	return truncate(fmt.Sprintf("%s | %d packets | %d dup | %d failed",
+		shortenAddress(relayer.Address), relayer.Packets, relayer.Redundant, relayer.Failed), lineWidth)
+}
@@ -0,0 +1,78 @@
+package cosmos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+)
+
+func recvPacket(sequence uint64, relayer string) DecodedMsg {
+	return DecodedMsg{
+		TypeURL: "/ibc.core.channel.v1.MsgRecvPacket",
+		Msg: &channeltypes.MsgRecvPacket{
+			Packet: channeltypes.Packet{
+				Sequence:           sequence,
+				SourcePort:         "transfer",
+				SourceChannel:      "channel-0",
+				DestinationPort:    "transfer",
+				DestinationChannel: "channel-141",
+			},
+			Signer: relayer,
+		},
+	}
+}
+
+func TestIBCTracker(t *testing.T) {
+	ack := DecodedMsg{
+		TypeURL: "/ibc.core.channel.v1.MsgAcknowledgement",
+		Msg: &channeltypes.MsgAcknowledgement{
+			Packet: channeltypes.Packet{Sequence: 3, SourcePort: "transfer", SourceChannel: "channel-0"},
+			Signer: "relayer-a",
+		},
+	}
+
+	now := time.Now()
+	a := &CosmosTransaction{Hash: "A", FirstSeen: now, Status: StatusTypeSuccess}
+	a.Packets = ibcPackets([]DecodedMsg{recvPacket(10, "relayer-a"), recvPacket(11, "relayer-a"), ack})
+	b := &CosmosTransaction{Hash: "B", FirstSeen: now, Status: StatusTypeFailed}
+	b.Packets = ibcPackets([]DecodedMsg{recvPacket(11, "relayer-b")})
+	require.Equal(t, "ibc recv×2 ack×1", formatPackets(a.Packets))
+
+	tracker := newIBCTracker()
+	tracker.observe(a)
+	tracker.observe(b)
+	channels, relayers := tracker.summarize()
+
+	require.Len(t, channels, 2)
+	require.Equal(t, "channel-141", channels[0].Channel)
+	require.Equal(t, 2, channels[0].Packets[PacketRecv])
+	require.Equal(t, uint64(11), channels[0].LastSequence)
+	require.Equal(t, 2, channels[0].Relayers)
+	require.Equal(t, 1, channels[0].Redundant)
+	require.Equal(t, "transfer/ch-141 | 2r | #11 | 2 rly | 1 dup", channelLine(channels[0]))
+	busy := ChannelSummary{
+		Port:         "transfer",
+		Channel:      "channel-141",
+		Packets:      map[PacketKind]int{PacketRecv: 45, PacketAck: 38, PacketTimeout: 2},
+		LastSequence: 2345678,
+		Relayers:     4,
+		Redundant:    3,
+	}
+	require.Equal(t, "transfer/ch-141 | 45r/38a/2t | #2345678 | 4 rly | 3 dup", channelLine(busy))
+	require.LessOrEqual(t, lipgloss.Width(channelLine(busy)), lineWidth)
+	require.Equal(t, "channel-0", channels[1].Channel)
+	require.Equal(t, 1, channels[1].Packets[PacketAck])
+
+	require.Equal(t, []RelayerSummary{
+		{Address: "relayer-a", Packets: 3, Redundant: 1},
+		{Address: "relayer-b", Packets: 1, Redundant: 1, Failed: 1},
+	}, relayers)
+
+	tracker.prune(now.Add(ibcRetention + time.Second))
+	channels, relayers = tracker.summarize()
+	require.Empty(t, channels)
+	require.Empty(t, relayers)
+}
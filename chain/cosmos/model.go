@@ -1,26 +1,81 @@
 package cosmos
 
 import (
+	"cmp"
 	"context"
-	"crypto/sha256"
 	"fmt"
 	"maps"
+	"regexp"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/cometbft/cometbft/abci/types"
 	"github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/technicallyty/xray/chain"
 )
 
 type CosmosTransaction struct {
-	Hash            string
-	Tx              *tx.Tx
+	Hash string
+	Raw  []byte
+	// Tx is nil for opaque txs that could not be decoded, DecodeErr says why.
+	Tx        *tx.Tx
+	DecodeErr error
+	Msgs      []DecodedMsg
+	// Eth is set for txs wrapping an Ethereum tx in a MsgEthereumTx.
+	Eth *EthTx
+	// Blobs is set for Celestia txs paying for blobs.
+	Blobs *PayForBlobs
+	// Bid is set for Block SDK auction bids, Lane is the lane the tx was matched to.
+	Bid  *AuctionBid
+	Lane string
+	// Execs are the authz MsgExecs of the tx, unwrapped.
+	Execs []Exec
+	// Packets are the IBC packets relayed by the tx.
+	Packets         []PacketMsg
+	Signers         []string
 	Status          StatusType
+	Result          *types.ExecTxResult // set once the tx was found in a block
+	FirstSeen       time.Time
 	TimeCompleted   time.Time
 	HeightCompleted int64
+	IndexCompleted  uint32
+	// FirstSeenHeight, LastSeenHeight and VanishedHeight bound the blocks produced while the tx was pending, 0 if unknown.
+	FirstSeenHeight int64
+	LastSeenHeight  int64
+	VanishedHeight  int64
+	// EvictionReason is set for evicted txs.
+	EvictionReason EvictionReason
+	// OffPage is set while the tx is tracked but beyond the page of a truncated mempool.
+	OffPage bool
+	// SequenceFlag is set while the tx is pending with a sequence that can't be included as is.
+	SequenceFlag SequenceFlag
+	// Expiry holds the timeouts of the tx, ExpiryState how close the pending tx is to them.
+	Expiry      Expiry
+	ExpiryState ExpiryState
+	// Watch is the memo watch rule the tx matched, empty if none did.
+	Watch  string
+	search searchIndex
+	// grantTallied is set once the fee of the tx was added to its fee granter's spend.
+	grantTallied bool
+}
+
+// applyResult sets the status of a tx that left the mempool from its inclusion result.
+func (tx *CosmosTransaction) applyResult(result *TxResult) {
+	z, ok := result.TxResult.(types.ExecTxResult)
+	if ok {
+		tx.Result = &z
+	}
+	if ok && z.Code == types.CodeTypeOK {
+		tx.Status = StatusTypeSuccess
+	} else {
+		tx.Status = StatusTypeFailed
+	}
+	tx.HeightCompleted = result.Height
+	tx.IndexCompleted = result.Index
 }
 
 type StatusType string
@@ -30,25 +85,129 @@ const (
 	StatusTypeSuccess   StatusType = "success"
 	StatusTypeFailed    StatusType = "failed"
 	StatusTypeEvicted   StatusType = "evicted"
+	StatusTypeExpired   StatusType = "expired"
 	StatusTypeUnknown   StatusType = "unknown"
 )
 
+// Options configures a CosmosModel.
+type Options struct {
+	// Bech32Prefix is the account address prefix signers are rendered with.
+	Bech32Prefix string
+	// MempoolSort is the order of the mempool box, by hash unless set.
+	MempoolSort MempoolSort
+	// MempoolTTLBlocks and MempoolTTLDuration mirror the node's mempool ttl-num-blocks and ttl-duration, 0 if unknown.
+	MempoolTTLBlocks   int64
+	MempoolTTLDuration time.Duration
+	// PriorityMempool is set when the node's mempool orders txs by priority, so higher priority txs can push a listed
+	// tx off the first page. CometBFT's default mempool is FIFO: a tx missing from the first page was removed.
+	PriorityMempool bool
+	// ExpiryWarnBlocks is how many blocks before their timeout pending txs are flagged, DefaultExpiryWarnBlocks if unset.
+	ExpiryWarnBlocks int64
+	// MemoWatch highlights mempool txs with a memo matching any of these and follows them to inclusion.
+	MemoWatch []*regexp.Regexp
+	// Lanes are the Block SDK lanes txs are matched to in priority order, DefaultLanes if unset.
+	Lanes []Lane
+}
+
 type CosmosModel struct {
 	client       *CosmosRPCClient
+	codec        *Codec
 	transactions map[string]*CosmosTransaction // hash -> transaction
 	completed    []*CosmosTransaction
 	name         string
 	pollingRate  time.Duration
+	mempoolSort  MempoolSort
+	// decodeFailures counts the distinct mempool txs that could not be decoded.
+	decodeFailures int
+	// awaiting holds txs that left the mempool and are waiting for their Tx event.
+	awaiting []*CosmosTransaction
+	// mempoolSize is the true size of the mempool, pageSize the number of txs listed from it.
+	mempoolSize MempoolSize
+	pageSize    int
+	truncated   bool
+	// offPageHeight is the height off-page txs were last looked up with /tx at.
+	offPageHeight   int64
+	priorityMempool bool
+	ttlBlocks       int64
+	ttlDuration     time.Duration
+	// expiryWarnBlocks is how early pending txs are flagged as expiring, blockInterval the observed block time.
+	expiryWarnBlocks int64
+	blockInterval    time.Duration
+	lastHeight       int64
+	lastHeightAt     time.Time
+	// accounts caches the next sequence of pending signers, signers summarizes them for display.
+	accounts map[string]accountSequence
+	signers  []SignerSummary
+	// ibc tracks relayed packets, channels and relayers summarize them for display.
+	ibc      *ibcTracker
+	channels []ChannelSummary
+	relayers []RelayerSummary
+	// namespaces totals the pending Celestia blobs by namespace.
+	namespaces []NamespaceSummary
+	// capacity is the block capacity from the consensus params at capacityHeight, forecast the blocks the mempool fills.
+	capacity       Capacity
+	capacityHeight int64
+	demandBytes    int64
+	demandGas      int64
+	forecast       Forecast
+	usage          []BlockUsage
+	// memoWatch are the memo watch rules, watched the txs that matched them. filter is set from the UI.
+	memoWatch []*regexp.Regexp
+	watched   []*CosmosTransaction
+	filter    txFilter
+	// lanes are the Block SDK lanes, laneSummaries the pending txs per lane. bidsSeen counts the auction bids seen,
+	// auctionHeight is the last block polled for an auction and auctions the latest auctions won.
+	lanes         []Lane
+	showLanes     bool
+	laneSummaries []LaneSummary
+	bidsSeen      int
+	auctionHeight int64
+	auctions      []AuctionResult
+	// grants tallies the fees paid by each fee granter over the session, granters summarizes them for display.
+	grants   map[string]*grantTally
+	granters []GranterSummary
+
+	// mu guards the state fed by block events.
+	mu             sync.Mutex
+	included       map[string]*TxResult // hash -> result from Tx events
+	latestHeight   int64
+	lastBlockEvent time.Time
+	blockTxs       map[int64][]string // height -> tx hashes, from block events or scanned for evictions
+	recentUsage    []BlockUsage       // usage of the latest blocks, from block events or queried
+	recentAuctions []AuctionResult    // auctions won in the latest blocks, from block events or queried
 }
 
-func NewCosmosModel(client *CosmosRPCClient, endpoint string, pollingRate time.Duration) *CosmosModel {
-	return &CosmosModel{
-		client:       client,
-		transactions: make(map[string]*CosmosTransaction),
-		completed:    make([]*CosmosTransaction, 0),
-		name:         fmt.Sprintf("Cosmos - %s", endpoint),
-		pollingRate:  pollingRate,
+func NewCosmosModel(client *CosmosRPCClient, endpoint string, pollingRate time.Duration, opts Options) (*CosmosModel, error) {
+	codec, err := NewCodec(opts.Bech32Prefix)
+	if err != nil {
+		return nil, err
 	}
+	c := &CosmosModel{
+		client:          client,
+		codec:           codec,
+		transactions:    make(map[string]*CosmosTransaction),
+		completed:       make([]*CosmosTransaction, 0),
+		included:        make(map[string]*TxResult),
+		blockTxs:        make(map[int64][]string),
+		accounts:        make(map[string]accountSequence),
+		ibc:             newIBCTracker(),
+		grants:          make(map[string]*grantTally),
+		name:            fmt.Sprintf("Cosmos - %s", endpoint),
+		pollingRate:     pollingRate,
+		mempoolSort:     opts.MempoolSort,
+		ttlBlocks:       opts.MempoolTTLBlocks,
+		priorityMempool: opts.PriorityMempool,
+		ttlDuration:     opts.MempoolTTLDuration,
+		memoWatch:       opts.MemoWatch,
+		lanes:           opts.Lanes,
+		showLanes:       len(opts.Lanes) > 0,
+
+		expiryWarnBlocks: cmp.Or(opts.ExpiryWarnBlocks, DefaultExpiryWarnBlocks),
+	}
+	if len(c.lanes) == 0 {
+		c.lanes = DefaultLanes
+	}
+	return c, nil
 }
 
 var (
@@ -60,9 +219,9 @@ var (
 	fadedStyleCosmos     = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Faint(true) // dim gray and faded
 
 	boxStyleCosmos = lipgloss.NewStyle().
-		Border(lipgloss.NormalBorder()).
-		Padding(0, 1).
-		Width(60)
+			Border(lipgloss.NormalBorder()).
+			Padding(0, 1).
+			Width(60)
 )
 
 func getStatusPrefixCosmos(status StatusType) string {
@@ -73,6 +232,8 @@ func getStatusPrefixCosmos(status StatusType) string {
 		return "✗ "
 	case StatusTypeEvicted:
 		return "⚠ "
+	case StatusTypeExpired:
+		return "⌛ "
 	case StatusTypeInMempool:
 		return ""
 	default:
@@ -80,12 +241,6 @@ func getStatusPrefixCosmos(status StatusType) string {
 	}
 }
 
-func getTxHash(tx *tx.Tx) string {
-	txBytes, _ := tx.Marshal()
-	hash := sha256.Sum256(txBytes)
-	return fmt.Sprintf("%X", hash) // Uppercase hex without 0x prefix for Cosmos
-}
-
 func truncateHash(hash string) string {
 	if len(hash) <= 10 {
 		return hash
@@ -93,7 +248,25 @@ func truncateHash(hash string) string {
 	return hash[:6] + "..." + hash[len(hash)-4:]
 }
 
+// formatBytes formats a size in bytes.
+func formatBytes(n int) string {
+	if n >= 1000000 {
+		return fmt.Sprintf("%.1fMB", float64(n)/1000000)
+	} else if n >= 1000 {
+		return fmt.Sprintf("%.1fKB", float64(n)/1000)
+	}
+	return fmt.Sprintf("%dB", n)
+}
+
+// truncate shortens s to at most n terminal cells, counting wide characters such as ⌛ as two.
+func truncate(s string, n int) string {
+	return ansi.Truncate(s, n, "…")
+}
+
 func formatMessageTypes(tx *tx.Tx) string {
+	if tx == nil {
+		return "opaque"
+	}
 	if tx.Body == nil || len(tx.Body.Messages) == 0 {
 		return "unknown"
 	}
@@ -114,96 +287,391 @@ func formatMessageTypes(tx *tx.Tx) string {
 	return strings.Join(msgTypes, ", ")
 }
 
+const (
+	// lineWidth is the width available to a row inside a box.
+	lineWidth = 58
+	// MempoolPageLimit is the most txs unconfirmed_txs returns, CometBFT caps it at 100 whatever the limit asked for.
+	MempoolPageLimit = 100
+	// offPageBlocks is how many blocks a tx may stay off the page of a priority mempool before it is taken as removed,
+	// when the mempool TTL is unknown.
+	offPageBlocks = 10
+)
+
 func (c *CosmosModel) Start(ctx context.Context) {
-	go func() {
-		ticker := time.NewTicker(c.pollingRate)
-		defer ticker.Stop()
-
-		for range ticker.C {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
-			currentTxs, err := c.client.MempoolTxs(ctx, 1000)
-			if err != nil {
-				continue
-			}
+	go c.watchEvents(ctx)
 
-			currentTxHashes := make(map[string]bool)
-			currentTxMap := make(map[string]*CosmosTransaction)
-			currentRemovedTxs := make(map[string]bool)
-
-			for _, tx := range currentTxs {
-				hash := getTxHash(tx)
-				currentTxHashes[hash] = true
-				currentTxMap[hash] = &CosmosTransaction{
-					Hash:   hash,
-					Tx:     tx,
-					Status: StatusTypeInMempool,
+	go chain.Poll(ctx, c.pollingRate, func() {
+		// the height is read before the mempool, so a tx in this mempool was not in any block before it
+		height := c.currentHeight(ctx)
+		currentTxs, size, err := c.client.MempoolTxs(ctx, MempoolPageLimit)
+		if err != nil {
+			return
+		}
+		// unconfirmed_txs has no offset, so txs beyond the first page can't be listed and may still be pending
+		truncated := len(currentTxs) < size.Txs
+
+		currentTxMap := make(map[string]*CosmosTransaction)
+
+		now := time.Now()
+		for _, tx := range currentTxs {
+			hash := tx.Hash
+			if prev, ok := c.transactions[hash]; ok {
+				if height > 0 {
+					prev.LastSeenHeight = height
 				}
+				prev.OffPage = false
+				currentTxMap[hash] = prev
+				continue
 			}
+			if tx.Tx == nil {
+				c.decodeFailures++
+			}
+			msgs := c.codec.DecodeMsgs(tx.Tx)
+			signers := c.codec.Signers(tx.Tx)
+			memo := txMemo(tx.Tx)
+			execs := c.codec.Execs(msgs)
+			newTx := &CosmosTransaction{
+				Hash:      hash,
+				Raw:       tx.Raw,
+				Tx:        tx.Tx,
+				DecodeErr: tx.DecodeErr,
+				Msgs:      msgs,
+				Eth:       unwrapEthTx(tx.Tx),
+				Blobs:     unwrapPayForBlobs(tx.Tx),
+				Bid:       unwrapAuctionBid(tx.Tx),
+				Lane:      laneOf(c.lanes, msgs, signers),
+				Execs:     execs,
+				Expiry:    txExpiry(sdkTxBytes(tx.Raw), tx.Tx),
+				Packets:   ibcPackets(msgs),
+				Signers:   signers,
+				Status:    StatusTypeInMempool,
+				FirstSeen: now,
+
+				FirstSeenHeight: height,
+				LastSeenHeight:  height,
 
-			for _, tx := range c.completed {
-				currentRemovedTxs[tx.Hash] = true
+				search: newSearchIndex(memo, msgs, signers),
+			}
+			// executed messages and their granters are searchable like the tx's own
+			for _, exec := range execs {
+				newTx.search = newTx.search.with(exec.Msgs, exec.Granters)
+			}
+			if rule := watchRule(c.memoWatch, memo); rule != nil {
+				newTx.Watch = rule.String()
+				c.follow(newTx)
 			}
+			if newTx.Bid != nil {
+				c.bidsSeen++
+			}
+			c.ibc.observe(newTx)
+			currentTxMap[hash] = newTx
+		}
 
-			// find transactions that are no longer in mempool
-			var removedTransactions []*CosmosTransaction
-			for hash, tx := range c.transactions {
-				if !currentTxHashes[hash] && !currentRemovedTxs[tx.Hash] {
-					tx.TimeCompleted = time.Now()
-					removedTransactions = append(removedTransactions, tx)
-				}
+		// find transactions that are no longer in mempool
+		removedTransactions, offPage := c.leftPage(currentTxMap, truncated, height)
+		included := c.settleOffPage(ctx, offPage, height)
+		c.tallyGrants(included)
+		c.completed = append(c.completed, included...)
+		for _, tx := range offPage {
+			if tx.Status == StatusTypeInMempool {
+				currentTxMap[tx.Hash] = tx
 			}
+		}
+		if len(removedTransactions) > 0 {
+			// read after the mempool, so the block that removed a tx is at or below it
+			vanishedHeight := c.currentHeight(ctx)
+			for _, tx := range removedTransactions {
+				tx.VanishedHeight = vanishedHeight
+			}
+		}
 
-			// check status of removed transactions
-			if len(removedTransactions) > 0 {
-				txHashes := make([]string, len(removedTransactions))
-				for i, tx := range removedTransactions {
-					txHashes[i] = tx.Hash
-				}
+		// txs still waiting for their Tx event get another chance
+		removedTransactions = append(c.awaiting, removedTransactions...)
+		c.awaiting = nil
 
-				var x int
-			line:
-				results, err := c.client.BatchTxStatus(ctx, txHashes)
-				if err != nil {
-					// error, assume transactions were evicted
-					for i := range removedTransactions {
-						removedTransactions[i].Status = StatusTypeUnknown
-					}
-					x++
-					if x < 5 {
-						goto line
-					}
+		// check status of removed transactions, from block events first and /tx otherwise
+		if len(removedTransactions) > 0 {
+			live := c.eventsLive()
+			var resolved, unresolved []*CosmosTransaction
+			for _, tx := range removedTransactions {
+				if result := c.includedResult(tx.Hash); result != nil {
+					tx.applyResult(result)
+					resolved = append(resolved, tx)
+				} else if live && time.Since(tx.TimeCompleted) < eventGracePeriod {
+					c.awaiting = append(c.awaiting, tx)
 				} else {
-					for i, result := range results {
-						if result == nil {
-							// tx not found, likely evicted
-							removedTransactions[i].Status = StatusTypeUnknown
-						} else {
-							z, ok := result.TxResult.(types.ExecTxResult)
-							if ok && z.Code == types.CodeTypeOK {
-								removedTransactions[i].Status = StatusTypeSuccess
-								removedTransactions[i].HeightCompleted = result.Height
-							} else {
-								removedTransactions[i].Status = StatusTypeFailed
-							}
-						}
-					}
+					unresolved = append(unresolved, tx)
 				}
+			}
+			c.queryStatus(ctx, unresolved)
+			resolved = append(resolved, unresolved...)
 
-				c.completed = append(c.completed, removedTransactions...)
+			c.mu.Lock()
+			c.pruneBlockTxs(height)
+			c.mu.Unlock()
 
-				const maxCompleted = 50
-				if len(c.completed) > maxCompleted {
-					c.completed = c.completed[len(c.completed)-maxCompleted:]
-				}
+			c.tallyGrants(resolved)
+			c.completed = append(c.completed, resolved...)
+		}
+
+		c.completed = chain.TrimCompleted(c.completed)
+
+		c.mempoolSize = size
+		c.pageSize = len(currentTxs)
+		c.truncated = truncated
+		c.signers = checkSequences(currentTxMap, c.refreshAccounts(ctx, currentTxMap, height))
+		c.ibc.prune(now)
+		c.observeHeight(height, now)
+		for _, tx := range currentTxMap {
+			tx.ExpiryState = tx.Expiry.state(height, now, c.expiryWarnBlocks, c.blockInterval)
+		}
+		c.channels, c.relayers = c.ibc.summarize()
+		c.namespaces = summarizeNamespaces(currentTxMap)
+
+		c.refreshCapacity(ctx, height)
+		c.observeUsage(ctx, height)
+		c.observeAuction(ctx, height)
+		c.mu.Lock()
+		c.usage = slices.Clone(c.recentUsage)
+		c.auctions = slices.Clone(c.recentAuctions)
+		c.mu.Unlock()
+		c.laneSummaries = summarizeLanes(c.lanes, currentTxMap)
+		c.granters = summarizeGrants(c.grants, currentTxMap)
+		c.demandBytes, c.demandGas = mempoolDemand(currentTxMap, c.mempoolSize, truncated)
+		c.forecast = forecast(c.demandBytes, c.demandGas, c.capacity, c.usage)
+		c.transactions = currentTxMap
+	})
+}
+
+// leftPage returns the tracked txs missing from the current page that were not completed yet: removed from the
+// mempool, or only off-page. unconfirmed_txs lists a FIFO mempool from its front, so a tx seen on the page can't be
+// pushed off it and is only taken as off-page when the page is truncated and the mempool orders txs by priority.
+// Off-page txs not seen for more than the mempool TTL, or offPageBlocks when unknown, are taken as removed.
+func (c *CosmosModel) leftPage(current map[string]*CosmosTransaction, truncated bool, height int64) (removed, offPage []*CosmosTransaction) {
+	completed := make(map[string]bool, len(c.completed))
+	for _, tx := range c.completed {
+		completed[tx.Hash] = true
+	}
+	maxBlocks := cmp.Or(c.ttlBlocks, offPageBlocks)
+	for _, tx := range chain.Removed(c.transactions, current) {
+		if completed[tx.Hash] {
+			continue
+		}
+		if truncated && c.priorityMempool && height-tx.LastSeenHeight <= maxBlocks {
+			offPage = append(offPage, tx)
+			continue
+		}
+		tx.TimeCompleted = time.Now()
+		removed = append(removed, tx)
+	}
+	return removed, offPage
+}
+
+// settleOffPage returns the off-page txs that were included, from Tx events or, once per block, from /tx.
+// The others are assumed to still be pending, since a truncated page can't tell them apart from removed txs.
+func (c *CosmosModel) settleOffPage(ctx context.Context, txs []*CosmosTransaction, height int64) []*CosmosTransaction {
+	var included, check []*CosmosTransaction
+	for _, tx := range txs {
+		tx.OffPage = true
+		if result := c.includedResult(tx.Hash); result != nil {
+			tx.TimeCompleted = time.Now()
+			tx.applyResult(result)
+			included = append(included, tx)
+		} else {
+			check = append(check, tx)
+		}
+	}
+	if len(check) == 0 || height <= c.offPageHeight {
+		return included
+	}
+	c.offPageHeight = height
+
+	txHashes := make([]string, len(check))
+	for i, tx := range check {
+		txHashes[i] = tx.Hash
+	}
+	results, err := c.client.BatchTxStatus(ctx, txHashes)
+	if err != nil {
+		return included
+	}
+	for i, result := range results {
+		if result != nil {
+			check[i].TimeCompleted = time.Now()
+			check[i].applyResult(result)
+			included = append(included, check[i])
+		}
+	}
+	return included
+}
+
+// queryStatus resolves the status of txs that left the mempool by querying /tx, scanning blocks for those it can't find.
+func (c *CosmosModel) queryStatus(ctx context.Context, txs []*CosmosTransaction) {
+	if len(txs) == 0 {
+		return
+	}
+	txHashes := make([]string, len(txs))
+	for i, tx := range txs {
+		txHashes[i] = tx.Hash
+	}
+
+	results, err := c.client.BatchTxStatus(ctx, txHashes)
+	for i, tx := range txs {
+		switch {
+		case err != nil:
+			tx.Status = StatusTypeUnknown
+		case results[i] == nil:
+			// tx not found, either evicted or not indexed
+			c.resolveVanished(ctx, tx)
+			if tx.Status != StatusTypeSuccess && tx.Status != StatusTypeFailed &&
+				tx.Expiry.expiredAt(tx.VanishedHeight, tx.TimeCompleted) {
+				tx.Status = StatusTypeExpired
 			}
+		default:
+			tx.applyResult(results[i])
+		}
+	}
+}
 
-			c.transactions = currentTxMap
+// observeHeight estimates the block interval from the heights seen while polling.
+func (c *CosmosModel) observeHeight(height int64, now time.Time) {
+	if height <= c.lastHeight {
+		return
+	}
+	if c.lastHeight > 0 {
+		interval := now.Sub(c.lastHeightAt) / time.Duration(height-c.lastHeight)
+		if c.blockInterval == 0 {
+			c.blockInterval = interval
+		} else {
+			c.blockInterval = (3*c.blockInterval + interval) / 4
 		}
-	}()
+	}
+	c.lastHeight, c.lastHeightAt = height, now
+}
+
+// mempoolHeader titles the mempool box with the true size of the mempool, noting when only its first page is listed.
+func (c *CosmosModel) mempoolHeader() string {
+	var header string
+	switch {
+	case c.truncated:
+		header = fmt.Sprintf("Mempool (first %d of %d txs, %s", c.pageSize, c.mempoolSize.Txs, formatBytes(int(c.mempoolSize.Bytes)))
+	case c.mempoolSize.Txs > 0:
+		header = fmt.Sprintf("Mempool (%d txs, %s", len(c.transactions), formatBytes(int(c.mempoolSize.Bytes)))
+	default:
+		header = fmt.Sprintf("Mempool (%d txs", len(c.transactions))
+	}
+	if c.decodeFailures > 0 {
+		header += fmt.Sprintf(", %d undecodable", c.decodeFailures)
+	}
+	if c.mempoolSort == MempoolSortGasPrice {
+		header += ", by gas price"
+	}
+	var expiring int
+	for _, tx := range c.transactions {
+		if tx.ExpiryState != ExpiryNone {
+			expiring++
+		}
+	}
+	if expiring > 0 {
+		header += fmt.Sprintf(", %d expiring", expiring)
+	}
+	if c.filter.active() {
+		var matched int
+		for _, tx := range c.transactions {
+			if c.filter.matches(tx) {
+				matched++
+			}
+		}
+		header += fmt.Sprintf(", %d match %q", matched, c.filter.query)
+	}
+	// an overloaded mempool is flagged in the capacity box
+	return truncate(header+")", lineWidth)
+}
+
+// msgSummary describes the messages of a tx for the message type column.
+func msgSummary(tx *CosmosTransaction) string {
+	switch {
+	case len(tx.Packets) > 0:
+		return formatPackets(tx.Packets)
+	case tx.Blobs != nil:
+		return formatBlobs(tx.Blobs)
+	case tx.Bid != nil:
+		return formatBid(tx.Bid)
+	case len(tx.Execs) > 0:
+		return formatExecs(tx.Execs)
+	}
+	return formatMessageTypes(tx.Tx)
+}
+
+// mempoolLine renders a pending tx as a row of the mempool box, truncated to the box width.
+func mempoolLine(tx *CosmosTransaction) string {
+	shortHash := truncateHash(tx.Hash)
+	if tx.Tx == nil {
+		return fadedStyleCosmos.Render(truncate(fmt.Sprintf("%s | opaque | %s", shortHash, formatBytes(len(tx.Raw))), lineWidth))
+	}
+	if tx.Eth != nil {
+		return inMempoolStyleCosmos.Render(ethLine(tx.Eth))
+	}
+	line := fmt.Sprintf("%s | %s | %s | %s %s",
+		shortHash, truncate(msgSummary(tx), 16), formatFee(tx.Tx)+feeMarker(tx.Tx), formatGas(gasLimit(tx.Tx)), formatGasPrice(tx.Tx))
+	switch {
+	case tx.Watch != "":
+		return watchedStyleCosmos.Render(truncate("◆ "+line, lineWidth))
+	case tx.ExpiryState == ExpiryPassed:
+		// will be dropped on the next recheck
+		return failedStyleCosmos.Render(truncate("⌛ "+line, lineWidth))
+	case tx.ExpiryState == ExpirySoon:
+		return evictedStyleCosmos.Render(truncate("⌛ "+line, lineWidth))
+	case tx.SequenceFlag != SequenceOK:
+		// will fail or wait on its sequence, see the signers box
+		return evictedStyleCosmos.Render(truncate("⚠ "+line, lineWidth))
+	}
+	return inMempoolStyleCosmos.Render(truncate(line, lineWidth))
+}
+
+// completedLine renders a tx that left the mempool as a row of the completed box, truncated to the box width.
+func completedLine(tx *CosmosTransaction) string {
+	shortHash := truncateHash(tx.Hash)
+	sequence := "?"
+	if tx.Tx != nil && tx.Tx.AuthInfo != nil && len(tx.Tx.AuthInfo.SignerInfos) > 0 {
+		sequences := make([]string, len(tx.Tx.AuthInfo.SignerInfos))
+		for i, info := range tx.Tx.AuthInfo.SignerInfos {
+			sequences[i] = fmt.Sprintf("%d", info.Sequence)
+		}
+		sequence = strings.Join(sequences, ",")
+	}
+	if tx.Eth != nil {
+		// the eth hash is what users look for, the inclusion below is tracked by the cosmos hash
+		shortHash = shortenEthHash(tx.Eth.Tx.Hash().Hex())
+		sequence = fmt.Sprintf("%d", tx.Eth.Tx.Nonce())
+	}
+	gasUsed := "-"
+	if tx.Result != nil {
+		gasUsed = formatGas(uint64(tx.Result.GasUsed))
+	}
+	inclusion := fmt.Sprintf("H%d#%d", tx.HeightCompleted, tx.IndexCompleted)
+	switch tx.Status {
+	case StatusTypeEvicted:
+		inclusion = string(tx.EvictionReason)
+	case StatusTypeExpired:
+		inclusion = "timeout"
+	}
+	// the gas column comes last so truncation cuts the least useful part
+	line := truncate(fmt.Sprintf("%s%s | %s | %s | %s | %s/%s",
+		getStatusPrefixCosmos(tx.Status), shortHash, truncate(msgSummary(tx), 16), sequence, inclusion, gasUsed, formatGas(gasLimit(tx.Tx))), lineWidth)
+
+	switch tx.Status {
+	case StatusTypeSuccess:
+		return successStyleCosmos.Render(line)
+	case StatusTypeFailed:
+		return failedStyleCosmos.Render(line)
+	case StatusTypeEvicted, StatusTypeExpired:
+		return evictedStyleCosmos.Render(line)
+	case StatusTypeInMempool:
+		return inMempoolStyleCosmos.Render(line)
+	case StatusTypeUnknown:
+		return fadedStyleCosmos.Render(line)
+	}
+	return line
 }
 
 func (c *CosmosModel) Displays() []string {
@@ -213,7 +681,7 @@ func (c *CosmosModel) Displays() []string {
 	// CURRENT MEMPOOL TRANSACTIONS UI
 	{
 		var lines []string
-		lines = append(lines, fmt.Sprintf("Mempool (%d txs)", len(c.transactions)))
+		lines = append(lines, c.mempoolHeader())
 		lines = append(lines, strings.Repeat("-", 50))
 
 		// Convert map to slice for consistent ordering
@@ -221,9 +689,22 @@ func (c *CosmosModel) Displays() []string {
 		for _, tx := range c.transactions {
 			txs = append(txs, tx)
 		}
+		txs = c.filter.filterTxs(txs)
 
-		// Sort by hash for consistent display order
+		// Sort by hash for consistent display order, or by gas price to see what validators will likely pick first.
+		// Watched txs come first so they are never cut off.
 		slices.SortFunc(txs, func(a, b *CosmosTransaction) int {
+			if (a.Watch != "") != (b.Watch != "") {
+				if a.Watch != "" {
+					return -1
+				}
+				return 1
+			}
+			if c.mempoolSort == MempoolSortGasPrice {
+				if order := compareGasPrice(a, b); order != 0 {
+					return order
+				}
+			}
 			return strings.Compare(a.Hash, b.Hash)
 		})
 
@@ -234,12 +715,7 @@ func (c *CosmosModel) Displays() []string {
 		}
 
 		for _, tx := range displayTxs {
-			shortHash := truncateHash(tx.Hash)
-			msgTypes := formatMessageTypes(tx.Tx)
-
-			line := fmt.Sprintf("%s | %s", shortHash, msgTypes)
-			line = inMempoolStyleCosmos.Render(line)
-			lines = append(lines, line)
+			lines = append(lines, mempoolLine(tx))
 		}
 
 		// Fill remaining lines to maintain consistent box height
@@ -263,10 +739,17 @@ func (c *CosmosModel) Displays() []string {
 			set[tx.Hash] = tx
 		}
 
-		completed = slices.Collect(maps.Values(set))
+		completed = c.filter.filterTxs(slices.Collect(maps.Values(set)))
 
+		source := "polling /tx"
+		if c.eventsLive() {
+			source = "block events"
+		}
+		if c.filter.active() {
+			source += fmt.Sprintf(", %d match", len(completed))
+		}
 		var lines []string
-		lines = append(lines, fmt.Sprintf("Completed Txs"))
+		lines = append(lines, fmt.Sprintf("Completed Txs (%s)", source))
 		lines = append(lines, strings.Repeat("-", 50))
 
 		slices.SortFunc(completed, func(a, b *CosmosTransaction) int {
@@ -278,49 +761,282 @@ func (c *CosmosModel) Displays() []string {
 			return 0 // they're equal
 		})
 
-		for i := range completed {
-			tx := completed[i]
-			shortHash := truncateHash(tx.Hash)
-			msgTypes := formatMessageTypes(tx.Tx)
-			prefix := getStatusPrefixCosmos(tx.Status)
+		for _, tx := range completed {
+			lines = append(lines, completedLine(tx))
+		}
 
-			var sequence string
-			if len(tx.Tx.AuthInfo.SignerInfos) > 0 {
-				sequence = fmt.Sprintf("%d", tx.Tx.AuthInfo.SignerInfos[0].Sequence)
-			} else {
-				sequence = "?"
+		// Fill remaining lines to maintain consistent box height
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+
+		content := strings.Join(lines, "\n")
+		displays = append(displays, boxStyleCosmos.Render(content))
+	}
+
+	// MEMO WATCH UI
+	if watched := c.watched; len(watched) > 0 {
+		var pending int
+		for _, tx := range watched {
+			if tx.Status == StatusTypeInMempool {
+				pending++
 			}
-			line := fmt.Sprintf("%s%s | %s | %s	| Height %d", prefix, shortHash, msgTypes, sequence, tx.HeightCompleted)
+		}
+		lines := []string{fmt.Sprintf("Watched memos (%d, %d pending)", len(watched), pending), strings.Repeat("-", 50)}
+		// newest first
+		for i := len(watched) - 1; i >= 0 && len(lines) < 10; i-- {
+			lines = append(lines, watchedLine(watched[i]))
+		}
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+		displays = append(displays, boxStyleCosmos.Render(strings.Join(lines, "\n")))
+	}
 
-			// Apply styling based on status
-			switch tx.Status {
-			case StatusTypeSuccess:
-				line = successStyleCosmos.Render(line)
-			case StatusTypeFailed:
-				line = failedStyleCosmos.Render(line)
-			case StatusTypeEvicted:
+	// BLOCK CAPACITY UI
+	if c.forecast.BytesCap > 0 || c.forecast.GasCap > 0 {
+		lines := capacityLines(c.forecast, c.demandBytes, c.demandGas, c.usage)
+		displays = append(displays, boxStyleCosmos.Render(strings.Join(lines, "\n")))
+	}
+
+	// FAILURE DETAIL UI
+	if tx, failure := c.latestFailure(); failure != nil {
+		displays = append(displays, boxStyleCosmos.Render(strings.Join(failureLines(tx, failure), "\n")))
+	}
+
+	// IBC RELAYING UI
+	if channels, relayers := c.channels, c.relayers; len(channels) > 0 {
+		var redundant int
+		for _, channel := range channels {
+			redundant += channel.Redundant
+		}
+		lines := []string{
+			fmt.Sprintf("IBC (%d channels, %d relayers, %d redundant)", len(channels), len(relayers), redundant),
+			strings.Repeat("-", 50),
+		}
+		// channels first, keeping a few rows for the busiest relayers
+		relayerRows := min(len(relayers), 3)
+		for _, channel := range channels[:min(len(channels), maxTxsPerBox-relayerRows)] {
+			line := channelLine(channel)
+			if channel.Redundant > 0 {
 				line = evictedStyleCosmos.Render(line)
-			case StatusTypeInMempool:
-				line = inMempoolStyleCosmos.Render(line)
-			case StatusTypeUnknown:
-				line = fadedStyleCosmos.Render(line)
 			}
+			lines = append(lines, line)
+		}
+		for _, relayer := range relayers[:relayerRows] {
+			lines = append(lines, fadedStyleCosmos.Render(relayerLine(relayer)))
+		}
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+		displays = append(displays, boxStyleCosmos.Render(strings.Join(lines, "\n")))
+	}
+
+	// CELESTIA BLOBS UI
+	if namespaces := c.namespaces; len(namespaces) > 0 {
+		var total int64
+		for _, namespace := range namespaces {
+			total += namespace.Bytes
+		}
+		lines := []string{
+			fmt.Sprintf("Blobs (%d namespaces, %s pending)", len(namespaces), formatBytes(int(total))),
+			strings.Repeat("-", 50),
+		}
+		for _, namespace := range namespaces[:min(len(namespaces), maxTxsPerBox)] {
+			lines = append(lines, namespaceLine(namespace))
+		}
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+		displays = append(displays, boxStyleCosmos.Render(strings.Join(lines, "\n")))
+	}
+
+	// BLOCK SDK LANES UI
+	if c.showLanes || c.bidsSeen > 0 {
+		var bids []*CosmosTransaction
+		for _, tx := range c.transactions {
+			if tx.Bid != nil {
+				bids = append(bids, tx)
+			}
+		}
+		// highest bid first, the one the auction is likely to pick
+		slices.SortFunc(bids, func(a, b *CosmosTransaction) int {
+			if order := b.Bid.Amount.Cmp(a.Bid.Amount); order != 0 {
+				return order
+			}
+			return strings.Compare(a.Hash, b.Hash)
+		})
+		lanes := c.laneSummaries
+		lines := []string{fmt.Sprintf("Lanes (%d, %d bids pending)", len(lanes), len(bids)), strings.Repeat("-", 50)}
+		for _, summary := range lanes[:min(len(lanes), maxTxsPerBox)] {
+			lines = append(lines, laneLine(summary))
+		}
+		for _, bid := range bids[:min(len(bids), 10-len(lines))] {
+			lines = append(lines, inMempoolStyleCosmos.Render(bidLine(bid)))
+		}
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+		displays = append(displays, boxStyleCosmos.Render(strings.Join(lines, "\n")))
+	}
 
+	// AUCTION WINNERS UI
+	if auctions := c.auctions; len(auctions) > 0 {
+		lines := []string{fmt.Sprintf("Auctions won (last at H%d)", auctions[len(auctions)-1].Height), strings.Repeat("-", 50)}
+		// newest first
+		for i := len(auctions) - 1; i >= 0 && len(lines) < 10; i-- {
+			line := auctionLine(auctions[i])
+			if auctions[i].Bundled {
+				line = successStyleCosmos.Render(line)
+			} else {
+				line = evictedStyleCosmos.Render(line)
+			}
 			lines = append(lines, line)
 		}
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+		displays = append(displays, boxStyleCosmos.Render(strings.Join(lines, "\n")))
+	}
 
-		// Fill remaining lines to maintain consistent box height
+	// FEE GRANTS UI
+	if granters := c.granters; len(granters) > 0 {
+		var pending int
+		for _, summary := range granters {
+			pending += summary.Pending
+		}
+		lines := []string{fmt.Sprintf("Fee grants (%d granters, %d pending)", len(granters), pending), strings.Repeat("-", 50)}
+		for _, summary := range granters[:min(len(granters), maxTxsPerBox)] {
+			lines = append(lines, granterLine(summary))
+		}
 		for len(lines) < 10 {
 			lines = append(lines, "")
 		}
+		displays = append(displays, boxStyleCosmos.Render(strings.Join(lines, "\n")))
+	}
 
-		content := strings.Join(lines, "\n")
-		displays = append(displays, boxStyleCosmos.Render(content))
+	// SIGNER SEQUENCES UI
+	if signers := c.signers; len(signers) > 0 {
+		var flagged int
+		for _, summary := range signers {
+			if summary.Flag != SequenceOK {
+				flagged++
+			}
+		}
+		lines := []string{fmt.Sprintf("Signers (%d, %d flagged)", len(signers), flagged), strings.Repeat("-", 50)}
+		for _, summary := range signers[:min(len(signers), maxTxsPerBox)] {
+			line := signerLine(summary)
+			if summary.Flag != SequenceOK {
+				line = evictedStyleCosmos.Render(line)
+			}
+			lines = append(lines, line)
+		}
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+		displays = append(displays, boxStyleCosmos.Render(strings.Join(lines, "\n")))
+	}
+
+	// TRANSACTION DETAIL UI
+	if detail := c.detailTx(); detail != nil {
+		displays = append(displays, boxStyleCosmos.Render(strings.Join(c.detailLines(detail), "\n")))
 	}
 
 	return displays
 }
 
+// latestFailure returns the most recently completed tx that failed, and its failure.
+func (c *CosmosModel) latestFailure() (*CosmosTransaction, *Failure) {
+	var latest *CosmosTransaction
+	for _, tx := range c.completed {
+		if tx.Status == StatusTypeFailed && tx.Result != nil &&
+			(latest == nil || !tx.TimeCompleted.Before(latest.TimeCompleted)) {
+			latest = tx
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	return latest, describeFailure(latest.Result)
+}
+
+// detailTx picks the tx to show in detail: the newest mempool tx, or the latest completed one.
+func (c *CosmosModel) detailTx() *CosmosTransaction {
+	var newest *CosmosTransaction
+	for _, tx := range c.transactions {
+		if newest == nil || tx.FirstSeen.After(newest.FirstSeen) ||
+			(tx.FirstSeen.Equal(newest.FirstSeen) && tx.Hash < newest.Hash) {
+			newest = tx
+		}
+	}
+	if newest == nil && len(c.completed) > 0 {
+		newest = c.completed[len(c.completed)-1]
+	}
+	return newest
+}
+
+// detailLines renders the signers and the JSON of every message of a tx.
+func (c *CosmosModel) detailLines(tx *CosmosTransaction) []string {
+	const maxLines = 10
+
+	var lines []string
+	lines = append(lines, fmt.Sprintf("Detail %s", truncateHash(tx.Hash)))
+	lines = append(lines, strings.Repeat("-", 50))
+	if tx.Tx == nil {
+		lines = append(lines, fmt.Sprintf("opaque tx of %s", formatBytes(len(tx.Raw))))
+		if tx.DecodeErr != nil {
+			lines = append(lines, wrapLines(tx.DecodeErr.Error(), lineWidth)...)
+		}
+	}
+	if tx.Status == StatusTypeEvicted {
+		lines = append(lines, evictedStyleCosmos.Render(evictionDetail(tx)))
+	}
+	if memo := txMemo(tx.Tx); memo != "" {
+		lines = append(lines, truncate("memo: "+memo, lineWidth))
+	}
+	if tx.Watch != "" {
+		lines = append(lines, watchedStyleCosmos.Render(truncate("watched by "+tx.Watch, lineWidth)))
+	}
+	if expiry := formatExpiry(tx.Expiry); expiry != "" {
+		lines = append(lines, expiry)
+	}
+	for _, signer := range tx.Signers {
+		lines = append(lines, "signer: "+signer)
+	}
+	if granter := feeGranter(tx.Tx); granter != "" {
+		lines = append(lines, "fee granter: "+granter)
+	}
+	if payer := feePayer(tx.Tx); payer != "" {
+		lines = append(lines, "fee payer: "+payer)
+	}
+	if tx.Eth != nil {
+		lines = append(lines, ethDetailLines(tx.Eth, tx.Hash)...)
+	}
+	if tx.Blobs != nil {
+		lines = append(lines, blobDetailLines(tx.Blobs)...)
+	}
+	if tx.Bid != nil {
+		lines = append(lines, bidDetailLines(tx.Bid)...)
+	}
+	lines = append(lines, c.codec.execDetailLines(tx.Execs, lineWidth)...)
+	for _, msg := range tx.Msgs {
+		if tx.Eth != nil && isMsgEthereumTx(msg.TypeURL) || tx.Blobs != nil && isMsgPayForBlobs(msg.TypeURL) ||
+			tx.Bid != nil && isMsgAuctionBid(msg.TypeURL) || len(tx.Execs) > 0 && isMsgExec(msg.TypeURL) {
+			continue
+		}
+		lines = append(lines, inMempoolStyleCosmos.Render(msg.TypeURL))
+		lines = append(lines, wrapLines(c.codec.MsgJSON(msg), lineWidth)...)
+	}
+
+	if len(lines) > maxLines {
+		lines = append(lines[:maxLines-1], fadedStyleCosmos.Render("…"))
+	}
+	for len(lines) < maxLines {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
 func (c *CosmosModel) Name() string {
 	return c.name
 }
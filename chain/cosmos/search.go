@@ -0,0 +1,173 @@
+package cosmos
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/technicallyty/xray/chain"
+)
+
+// maxWatched is the number of watched txs followed once they leave the mempool.
+const maxWatched = 20
+
+var watchedStyleCosmos = lipgloss.NewStyle().Foreground(lipgloss.Color("213")) // pink
+
+// searchIndex holds the searchable fields of a tx, lowercased once when the tx is first seen.
+type searchIndex struct {
+	memo    string
+	types   []string
+	signers []string
+}
+
+func newSearchIndex(memo string, msgs []DecodedMsg, signers []string) searchIndex {
+	index := searchIndex{memo: strings.ToLower(memo)}
+	for _, msg := range msgs {
+		index.types = append(index.types, strings.ToLower(msg.TypeURL))
+	}
+	for _, signer := range signers {
+		index.signers = append(index.signers, strings.ToLower(signer))
+	}
+	return index
+}
+
+// with returns the index extended with more message types and signers, such as those of executed authz messages.
+func (index searchIndex) with(msgs []DecodedMsg, signers []string) searchIndex {
+	more := newSearchIndex("", msgs, signers)
+	index.types = append(slices.Clone(index.types), more.types...)
+	index.signers = append(slices.Clone(index.signers), more.signers...)
+	return index
+}
+
+// txMemo returns the memo of a decoded tx.
+func txMemo(decoded *tx.Tx) string {
+	if decoded == nil || decoded.Body == nil {
+		return ""
+	}
+	return decoded.Body.Memo
+}
+
+// filterTerm matches one field of a tx, or any of them when field is empty.
+type filterTerm struct {
+	field string
+	value string
+}
+
+// txFilter is a parsed search. A tx matches when every term matches it.
+type txFilter struct {
+	query string
+	terms []filterTerm
+}
+
+// parseFilter parses a search of space separated terms, each a substring of the memo, a message type URL or a
+// signer address. A term can be limited to one of them with a "memo:", "type:" or "signer:" prefix.
+func parseFilter(query string) txFilter {
+	filter := txFilter{query: strings.TrimSpace(query)}
+	for _, word := range strings.Fields(strings.ToLower(filter.query)) {
+		term := filterTerm{value: word}
+		if field, value, ok := strings.Cut(word, ":"); ok && slices.Contains([]string{"memo", "type", "signer"}, field) {
+			term = filterTerm{field: field, value: value}
+		}
+		filter.terms = append(filter.terms, term)
+	}
+	return filter
+}
+
+func (f txFilter) active() bool {
+	return len(f.terms) > 0
+}
+
+func (f txFilter) matches(tx *CosmosTransaction) bool {
+	for _, term := range f.terms {
+		if !term.matches(tx.search) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t filterTerm) matches(index searchIndex) bool {
+	contains := func(values []string) bool {
+		return slices.ContainsFunc(values, func(v string) bool { return strings.Contains(v, t.value) })
+	}
+	switch t.field {
+	case "memo":
+		return strings.Contains(index.memo, t.value)
+	case "type":
+		return contains(index.types)
+	case "signer":
+		return contains(index.signers)
+	default:
+		return strings.Contains(index.memo, t.value) || contains(index.types) || contains(index.signers)
+	}
+}
+
+// filterTxs returns the txs matching the filter, or all of them when no filter is set.
+func (f txFilter) filterTxs(txs []*CosmosTransaction) []*CosmosTransaction {
+	if !f.active() {
+		return txs
+	}
+	var matched []*CosmosTransaction
+	for _, tx := range txs {
+		if f.matches(tx) {
+			matched = append(matched, tx)
+		}
+	}
+	return matched
+}
+
+// SetFilter filters the mempool and completed boxes by memo, message type and signer as the search is typed.
+// It is called from the UI, like Displays.
+func (c *CosmosModel) SetFilter(query string) {
+	c.filter = parseFilter(query)
+}
+
+// watchRule returns the first memo watch rule matching a memo, nil if none does.
+func watchRule(rules []*regexp.Regexp, memo string) *regexp.Regexp {
+	if memo == "" {
+		return nil
+	}
+	for _, rule := range rules {
+		if rule.MatchString(memo) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// follow keeps a watched tx seen for the first time, forgetting the oldest beyond maxWatched.
+func (c *CosmosModel) follow(tx *CosmosTransaction) {
+	c.watched = append(c.watched, tx)
+	if len(c.watched) > maxWatched {
+		c.watched = c.watched[len(c.watched)-maxWatched:]
+	}
+}
+
+// watchedLine renders a watched tx, pending or completed, as a row of the watched box.
+func watchedLine(tx *CosmosTransaction) string {
+	state := "pending"
+	switch tx.Status {
+	case StatusTypeSuccess, StatusTypeFailed:
+		state = fmt.Sprintf("H%d#%d", tx.HeightCompleted, tx.IndexCompleted)
+	case StatusTypeEvicted, StatusTypeExpired, StatusTypeUnknown:
+		state = string(tx.Status)
+	}
+	line := fmt.Sprintf("%s%s | %s | %s", getStatusPrefixCosmos(tx.Status), truncateHash(tx.Hash), truncate(txMemo(tx.Tx), 24), state)
+	switch tx.Status {
+	case StatusTypeSuccess:
+		return successStyleCosmos.Render(line)
+	case StatusTypeFailed:
+		return failedStyleCosmos.Render(line)
+	case StatusTypeEvicted, StatusTypeExpired:
+		return evictedStyleCosmos.Render(line)
+	case StatusTypeUnknown:
+		return fadedStyleCosmos.Render(line)
+	default:
+		return watchedStyleCosmos.Render(line)
+	}
+}
+
+var _ chain.Filterable = &CosmosModel{}
@@ -0,0 +1,39 @@
+package cosmos
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxFilter(t *testing.T) {
+	send := &CosmosTransaction{Hash: "A", search: newSearchIndex("Deposit 12345",
+		[]DecodedMsg{{TypeURL: "/cosmos.bank.v1beta1.MsgSend"}}, []string{"cosmos1alice"})}
+	delegate := &CosmosTransaction{Hash: "B", search: newSearchIndex("",
+		[]DecodedMsg{{TypeURL: "/cosmos.staking.v1beta1.MsgDelegate"}}, []string{"cosmos1bob"})}
+	txs := []*CosmosTransaction{send, delegate}
+
+	require.Equal(t, txs, parseFilter("  ").filterTxs(txs))
+	require.Equal(t, []*CosmosTransaction{send}, parseFilter("deposit").filterTxs(txs))
+	require.Equal(t, []*CosmosTransaction{delegate}, parseFilter("type:msgdelegate").filterTxs(txs))
+	require.Equal(t, []*CosmosTransaction{send}, parseFilter("signer:alice MsgSend").filterTxs(txs))
+	require.Empty(t, parseFilter("memo:bob").filterTxs(txs))
+	require.Empty(t, parseFilter("signer:alice msgdelegate").filterTxs(txs))
+	// unknown prefixes are searched as is
+	require.Empty(t, parseFilter("to:bob").filterTxs(txs))
+}
+
+func TestWatchRule(t *testing.T) {
+	rules := []*regexp.Regexp{regexp.MustCompile(`^\d{6}$`), regexp.MustCompile(`(?i)deposit`)}
+	require.Equal(t, rules[0], watchRule(rules, "104233"))
+	require.Equal(t, rules[1], watchRule(rules, "DEPOSIT 7"))
+	require.Nil(t, watchRule(rules, "hello"))
+	require.Nil(t, watchRule(rules, ""))
+
+	c := &CosmosModel{}
+	for i := 0; i < maxWatched+5; i++ {
+		c.follow(&CosmosTransaction{})
+	}
+	require.Len(t, c.watched, maxWatched)
+}
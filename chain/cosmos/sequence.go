@@ -0,0 +1,246 @@
+package cosmos
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// maxAccountQueries is the most x/auth account queries made per poll.
+const maxAccountQueries = 20
+
+// SequenceFlag marks a mempool tx whose signer sequence can't be included as is.
+type SequenceFlag string
+
+const (
+	SequenceOK SequenceFlag = ""
+	// SequenceStale is a sequence already used on chain, the tx will fail with "account sequence mismatch".
+	SequenceStale SequenceFlag = "stale"
+	// SequenceDuplicate is a sequence used by another pending tx of the same signer, only one of them can be included.
+	SequenceDuplicate SequenceFlag = "dup"
+	// SequenceGap is a sequence after a missing one, the tx can't be included until the gap is filled.
+	SequenceGap SequenceFlag = "gap"
+)
+
+// severity orders flags from harmless to certain failure.
+func (f SequenceFlag) severity() int {
+	switch f {
+	case SequenceStale:
+		return 3
+	case SequenceDuplicate:
+		return 2
+	case SequenceGap:
+		return 1
+	}
+	return 0
+}
+
+// worse returns the more severe of two flags.
+func (f SequenceFlag) worse(other SequenceFlag) SequenceFlag {
+	if other.severity() > f.severity() {
+		return other
+	}
+	return f
+}
+
+// accountSequence is the next sequence of an account as of a block height.
+type accountSequence struct {
+	next   uint64
+	height int64
+	// ok is false if the account could not be queried or decoded.
+	ok bool
+}
+
+// SignerSummary is the sequence state of a signer with txs in the mempool.
+type SignerSummary struct {
+	Address string
+	// Next is the sequence the chain expects next, valid if Known.
+	Next  uint64
+	Known bool
+	// Pending are the sequences of the signer's mempool txs, sorted, with duplicates.
+	Pending []uint64
+	// Flag is the worst flag of the signer's txs.
+	Flag SequenceFlag
+}
+
+// signerSequences returns the sequence signed by every signer of the tx whose address is known.
+func signerSequences(tx *CosmosTransaction) map[string]uint64 {
+	if tx.Tx == nil || tx.Tx.AuthInfo == nil || tx.Expiry.Unordered {
+		// unordered txs are replay protected by their timeout, not their sequence
+		return nil
+	}
+	sequences := make(map[string]uint64)
+	for i, info := range tx.Tx.AuthInfo.SignerInfos {
+		if i < len(tx.Signers) && tx.Signers[i] != "?" {
+			sequences[tx.Signers[i]] = info.Sequence
+		}
+	}
+	return sequences
+}
+
+// checkSequences sets the SequenceFlag of every tx from the pending sequences of its signers and their next
+// sequence on chain, and summarizes every signer. Duplicates are flagged even when the next sequence is unknown.
+func checkSequences(txs map[string]*CosmosTransaction, next map[string]uint64) []SignerSummary {
+	pending := make(map[string][]uint64)
+	for _, tx := range txs {
+		tx.SequenceFlag = SequenceOK
+		for signer, sequence := range signerSequences(tx) {
+			pending[signer] = append(pending[signer], sequence)
+		}
+	}
+
+	flag := func(signer string, sequence uint64) SequenceFlag {
+		sequences := pending[signer]
+		expected, known := next[signer]
+		switch {
+		case known && sequence < expected:
+			return SequenceStale
+		case countOf(sequences, sequence) > 1:
+			return SequenceDuplicate
+		case known:
+			for s := expected; s < sequence; s++ {
+				if !slices.Contains(sequences, s) {
+					return SequenceGap
+				}
+			}
+		}
+		return SequenceOK
+	}
+
+	summaries := make(map[string]*SignerSummary, len(pending))
+	for signer, sequences := range pending {
+		slices.Sort(sequences)
+		expected, known := next[signer]
+		summaries[signer] = &SignerSummary{Address: signer, Next: expected, Known: known, Pending: sequences}
+	}
+	for _, tx := range txs {
+		for signer, sequence := range signerSequences(tx) {
+			f := flag(signer, sequence)
+			tx.SequenceFlag = tx.SequenceFlag.worse(f)
+			summaries[signer].Flag = summaries[signer].Flag.worse(f)
+		}
+	}
+
+	result := make([]SignerSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, *summary)
+	}
+	// flagged signers first, then the busiest
+	slices.SortFunc(result, func(a, b SignerSummary) int {
+		if order := cmp.Compare(b.Flag.severity(), a.Flag.severity()); order != 0 {
+			return order
+		}
+		if order := cmp.Compare(len(b.Pending), len(a.Pending)); order != 0 {
+			return order
+		}
+		return strings.Compare(a.Address, b.Address)
+	})
+	return result
+}
+
+func countOf(sequences []uint64, sequence uint64) int {
+	var n int
+	for _, s := range sequences {
+		if s == sequence {
+			n++
+		}
+	}
+	return n
+}
+
+// refreshAccounts queries the next sequence of the signers of txs that is unknown or older than height,
+// at most maxAccountQueries per call, never-queried signers first, and returns the sequences that are current.
+// Signers without pending txs are forgotten.
+func (c *CosmosModel) refreshAccounts(ctx context.Context, txs map[string]*CosmosTransaction, height int64) map[string]uint64 {
+	signers := make(map[string]bool)
+	for _, tx := range txs {
+		for signer := range signerSequences(tx) {
+			signers[signer] = true
+		}
+	}
+	for signer := range c.accounts {
+		if !signers[signer] {
+			delete(c.accounts, signer)
+		}
+	}
+
+	var stale []string
+	for signer := range signers {
+		account, ok := c.accounts[signer]
+		if !ok || account.height < height {
+			stale = append(stale, signer)
+		}
+	}
+	slices.SortFunc(stale, func(a, b string) int {
+		_, knownA := c.accounts[a]
+		_, knownB := c.accounts[b]
+		if knownA != knownB {
+			if knownA {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a, b)
+	})
+
+	for _, signer := range stale[:min(len(stale), maxAccountQueries)] {
+		account := accountSequence{height: height}
+		if acc, err := c.client.Account(ctx, signer); err == nil {
+			if sequence, err := c.codec.AccountSequence(acc); err == nil {
+				account.next, account.ok = sequence, true
+			}
+		}
+		c.accounts[signer] = account
+	}
+
+	// sequences of older blocks would flag txs following ones included since as gaps
+	next := make(map[string]uint64, len(c.accounts))
+	for signer, account := range c.accounts {
+		if account.ok && account.height >= height {
+			next[signer] = account.next
+		}
+	}
+	return next
+}
+
+// formatSequences compresses sorted sequences into ranges, e.g. "12-14,16".
+func formatSequences(sequences []uint64) string {
+	sequences = slices.Compact(slices.Clone(sequences))
+	var parts []string
+	for i := 0; i < len(sequences); {
+		j := i
+		for j+1 < len(sequences) && sequences[j+1] == sequences[j]+1 {
+			j++
+		}
+		if j == i {
+			parts = append(parts, fmt.Sprintf("%d", sequences[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", sequences[i], sequences[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// shortenAddress keeps the prefix and the end of a bech32 address.
+func shortenAddress(address string) string {
+	if len(address) <= 20 {
+		return address
+	}
+	return address[:12] + ".." + address[len(address)-6:]
+}
+
+// signerLine renders a signer summary as a row of the signers box.
+func signerLine(summary SignerSummary) string {
+	next := "?"
+	if summary.Known {
+		next = fmt.Sprintf("%d", summary.Next)
+	}
+	line := fmt.Sprintf("%s | next %s | pending %s", shortenAddress(summary.Address), next, truncate(formatSequences(summary.Pending), 12))
+	if summary.Flag != SequenceOK {
+		line += " | " + string(summary.Flag)
+	}
+	return line
+}
@@ -0,0 +1,63 @@
+package cosmos
+
+import (
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/require"
+)
+
+func signedTx(hash, signer string, sequence uint64) *CosmosTransaction {
+	return &CosmosTransaction{
+		Hash:    hash,
+		Tx:      &tx.Tx{AuthInfo: &tx.AuthInfo{SignerInfos: []*tx.SignerInfo{{Sequence: sequence}}}},
+		Signers: []string{signer},
+	}
+}
+
+func TestCheckSequences(t *testing.T) {
+	txs := map[string]*CosmosTransaction{
+		"A4":  signedTx("A4", "alice", 4),
+		"A5":  signedTx("A5", "alice", 5),
+		"A7":  signedTx("A7", "alice", 7),
+		"B2":  signedTx("B2", "bob", 2),
+		"C1":  signedTx("C1", "carol", 1),
+		"C1'": signedTx("C1'", "carol", 1),
+		"D9":  signedTx("D9", "dave", 9),
+	}
+	summaries := checkSequences(txs, map[string]uint64{"alice": 5, "bob": 2})
+
+	require.Equal(t, SequenceStale, txs["A4"].SequenceFlag)
+	require.Equal(t, SequenceOK, txs["A5"].SequenceFlag)
+	require.Equal(t, SequenceGap, txs["A7"].SequenceFlag)
+	require.Equal(t, SequenceOK, txs["B2"].SequenceFlag)
+	require.Equal(t, SequenceDuplicate, txs["C1"].SequenceFlag)
+	require.Equal(t, SequenceDuplicate, txs["C1'"].SequenceFlag)
+	require.Equal(t, SequenceOK, txs["D9"].SequenceFlag)
+
+	require.Len(t, summaries, 4)
+	require.Equal(t, "alice", summaries[0].Address)
+	require.Equal(t, SequenceStale, summaries[0].Flag)
+	require.Equal(t, []uint64{4, 5, 7}, summaries[0].Pending)
+	require.Equal(t, "carol", summaries[1].Address)
+	require.False(t, summaries[1].Known)
+	require.Equal(t, "alice | next 5 | pending 4-5,7 | stale", signerLine(summaries[0]))
+}
+
+func TestAccountSequence(t *testing.T) {
+	account, err := codectypes.NewAnyWithValue(&authtypes.BaseAccount{Address: "cosmos1abc", Sequence: 42})
+	require.NoError(t, err)
+
+	c, err := NewCodec("")
+	require.NoError(t, err)
+	sequence, err := c.AccountSequence(account)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), sequence)
+}
+
+func TestFormatSequences(t *testing.T) {
+	require.Equal(t, "1-3,5,7-8", formatSequences([]uint64{1, 2, 2, 3, 5, 7, 8}))
+	require.Equal(t, "", formatSequences(nil))
+}
@@ -2,6 +2,7 @@ package eth
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -63,6 +64,10 @@ type Transaction struct {
 	PoolName string
 	Status   StatusType
 	Data     *RPCTransaction
+	L1Fee    *big.Int       // L1 data fee on OP Stack chains, nil if unknown
+	Receipt  *types.Receipt // set once the tx left the pool and was included
+	// Prediction is the simulated outcome of the tx, nil if it was not simulated.
+	Prediction *Prediction
 }
 
 type StatusType string
@@ -0,0 +1,350 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type MEVKind string
+
+const (
+	MEVKindSandwich MEVKind = "sandwich"
+	MEVKindFrontrun MEVKind = "frontrun"
+)
+
+type MEVOutcome string
+
+const (
+	MEVOutcomePending   MEVOutcome = "pending"
+	MEVOutcomeConfirmed MEVOutcome = "confirmed"
+	MEVOutcomeMissed    MEVOutcome = "missed"
+)
+
+const (
+	// maxMEVGroupSize bounds the number of pending txs per target contract that are searched for sandwiches.
+	maxMEVGroupSize = 64
+	// maxMEVSuspects is the number of suspects kept, resolved ones are dropped first.
+	maxMEVSuspects = 50
+)
+
+// MEVSuspect is a set of pending transactions that look like a sandwich or a frontrun.
+type MEVSuspect struct {
+	Kind   MEVKind
+	Target common.Address
+	// Attackers are the front and back legs of a sandwich, or the frontrunning tx.
+	Attackers []common.Hash
+	Victim    common.Hash
+	FirstSeen time.Time
+	Outcome   MEVOutcome
+}
+
+func (s *MEVSuspect) key() string {
+	parts := []string{string(s.Kind), s.Victim.Hex()}
+	for _, h := range s.Attackers {
+		parts = append(parts, h.Hex())
+	}
+	return strings.Join(parts, ":")
+}
+
+func (s *MEVSuspect) hashes() []common.Hash {
+	return append([]common.Hash{s.Victim}, s.Attackers...)
+}
+
+// MEVDetector looks for sandwich and frontrun setups in the pending pool and confirms them after inclusion.
+type MEVDetector struct {
+	mu       sync.Mutex
+	suspects map[string]*MEVSuspect
+	// receipts holds the receipts of suspect txs that already left the pool.
+	// A nil receipt means the tx left the pool without being included.
+	receipts map[common.Hash]*types.Receipt
+	// tracked holds every tx that is part of a suspect.
+	tracked map[common.Hash]bool
+}
+
+func NewMEVDetector() *MEVDetector {
+	return &MEVDetector{
+		suspects: make(map[string]*MEVSuspect),
+		receipts: make(map[common.Hash]*types.Receipt),
+		tracked:  make(map[common.Hash]bool),
+	}
+}
+
+// effectiveTip is the priority fee a tx offers, falling back to the gas price for legacy txs.
+func effectiveTip(tx *RPCTransaction) *big.Int {
+	if tx.GasTipCap != nil {
+		return tx.GasTipCap.ToInt()
+	}
+	if tx.GasPrice != nil {
+		return tx.GasPrice.ToInt()
+	}
+	return new(big.Int)
+}
+
+func selector(tx *RPCTransaction) string {
+	if len(tx.Input) < 4 {
+		return ""
+	}
+	return string(tx.Input[:4])
+}
+
+// areRelated reports whether the front and back legs of a possible sandwich around victim come from the same operator:
+// either the same sender, or different senders calling the same function, which the victim does not call.
+func areRelated(front, back, victim *RPCTransaction) bool {
+	if front.From == back.From {
+		return true
+	}
+	sel := selector(front)
+	return sel != "" && sel == selector(back) && sel != selector(victim)
+}
+
+// Detect searches the pending txs for new suspects.
+func (d *MEVDetector) Detect(pending []*RPCTransaction, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	byTarget := make(map[common.Address][]*RPCTransaction)
+	for _, tx := range pending {
+		if tx.To == nil {
+			continue
+		}
+		byTarget[*tx.To] = append(byTarget[*tx.To], tx)
+	}
+
+	for target, txs := range byTarget {
+		if len(txs) < 2 {
+			continue
+		}
+		// highest tip first, so attackers in a sandwich are found in front of their victims.
+		slices.SortFunc(txs, func(a, b *RPCTransaction) int {
+			if c := effectiveTip(b).Cmp(effectiveTip(a)); c != 0 {
+				return c
+			}
+			return a.Hash.Cmp(b.Hash)
+		})
+		d.detectFrontruns(target, txs, now)
+		if len(txs) > maxMEVGroupSize {
+			txs = txs[:maxMEVGroupSize]
+		}
+		d.detectSandwiches(target, txs, now)
+	}
+}
+
+// detectSandwiches finds a victim bracketed by a higher-tip and a lower-tip tx from the same or related senders.
+// txs must be sorted by tip, highest first.
+func (d *MEVDetector) detectSandwiches(target common.Address, txs []*RPCTransaction, now time.Time) {
+	for i, front := range txs {
+		for k := len(txs) - 1; k > i+1; k-- {
+			back := txs[k]
+			if effectiveTip(back).Cmp(effectiveTip(front)) >= 0 {
+				continue
+			}
+			for _, victim := range txs[i+1 : k] {
+				if victim.From == front.From || victim.From == back.From || !areRelated(front, back, victim) {
+					continue
+				}
+				tip := effectiveTip(victim)
+				if tip.Cmp(effectiveTip(front)) >= 0 || tip.Cmp(effectiveTip(back)) <= 0 {
+					continue
+				}
+				d.add(&MEVSuspect{
+					Kind:      MEVKindSandwich,
+					Target:    target,
+					Attackers: []common.Hash{front.Hash, back.Hash},
+					Victim:    victim.Hash,
+					FirstSeen: now,
+					Outcome:   MEVOutcomePending,
+				})
+			}
+		}
+	}
+}
+
+// detectFrontruns finds txs from different senders with identical calldata, where the highest tip is the frontrunner.
+// txs must be sorted by tip, highest first.
+func (d *MEVDetector) detectFrontruns(target common.Address, txs []*RPCTransaction, now time.Time) {
+	byCalldata := make(map[common.Hash][]*RPCTransaction)
+	for _, tx := range txs {
+		if len(tx.Input) < 4 {
+			continue
+		}
+		key := crypto.Keccak256Hash(tx.Input)
+		byCalldata[key] = append(byCalldata[key], tx)
+	}
+
+	for _, dupes := range byCalldata {
+		if len(dupes) < 2 {
+			continue
+		}
+		attacker := dupes[0]
+		for _, victim := range dupes[1:] {
+			if victim.From == attacker.From || effectiveTip(victim).Cmp(effectiveTip(attacker)) >= 0 {
+				continue
+			}
+			d.add(&MEVSuspect{
+				Kind:      MEVKindFrontrun,
+				Target:    target,
+				Attackers: []common.Hash{attacker.Hash},
+				Victim:    victim.Hash,
+				FirstSeen: now,
+				Outcome:   MEVOutcomePending,
+			})
+		}
+	}
+}
+
+func (d *MEVDetector) add(suspect *MEVSuspect) {
+	key := suspect.key()
+	if _, ok := d.suspects[key]; ok {
+		return
+	}
+	d.suspects[key] = suspect
+	for _, hash := range suspect.hashes() {
+		d.tracked[hash] = true
+	}
+}
+
+// Resolve records the receipts of txs that left the pool and confirms suspects once all of their txs are resolved.
+func (d *MEVDetector) Resolve(removed []*Transaction) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, tx := range removed {
+		if d.tracked[tx.Data.Hash] {
+			d.receipts[tx.Data.Hash] = tx.Receipt
+		}
+	}
+
+	for _, suspect := range d.suspects {
+		if suspect.Outcome != MEVOutcomePending {
+			continue
+		}
+		resolved := true
+		for _, hash := range suspect.hashes() {
+			if _, ok := d.receipts[hash]; !ok {
+				resolved = false
+				break
+			}
+		}
+		if !resolved {
+			continue
+		}
+
+		if d.confirmed(suspect) {
+			suspect.Outcome = MEVOutcomeConfirmed
+		} else {
+			suspect.Outcome = MEVOutcomeMissed
+		}
+	}
+
+	d.prune()
+}
+
+// confirmed checks the inclusion order of a resolved suspect.
+func (d *MEVDetector) confirmed(suspect *MEVSuspect) bool {
+	victim := d.receipts[suspect.Victim]
+	if victim == nil {
+		return false
+	}
+	switch suspect.Kind {
+	case MEVKindSandwich:
+		front, back := d.receipts[suspect.Attackers[0]], d.receipts[suspect.Attackers[1]]
+		if front == nil || back == nil {
+			return false
+		}
+		sameBlock := front.BlockHash == victim.BlockHash && back.BlockHash == victim.BlockHash
+		return sameBlock && front.TransactionIndex < victim.TransactionIndex && victim.TransactionIndex < back.TransactionIndex
+	case MEVKindFrontrun:
+		attacker := d.receipts[suspect.Attackers[0]]
+		if attacker == nil || attacker.Status != types.ReceiptStatusSuccessful {
+			return false
+		}
+		if attacker.BlockHash == victim.BlockHash {
+			return attacker.TransactionIndex < victim.TransactionIndex
+		}
+		return attacker.BlockNumber.Cmp(victim.BlockNumber) < 0
+	}
+	return false
+}
+
+// prune drops the oldest suspects, resolved ones first, and forgets the txs no remaining suspect refers to.
+func (d *MEVDetector) prune() {
+	if len(d.suspects) <= maxMEVSuspects {
+		return
+	}
+	suspects := d.sorted()
+	slices.SortStableFunc(suspects, func(a, b *MEVSuspect) int {
+		aPending, bPending := a.Outcome == MEVOutcomePending, b.Outcome == MEVOutcomePending
+		if aPending != bPending {
+			if aPending {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	})
+	for _, suspect := range suspects[maxMEVSuspects:] {
+		delete(d.suspects, suspect.key())
+	}
+
+	referenced := make(map[common.Hash]bool)
+	for _, suspect := range d.suspects {
+		for _, hash := range suspect.hashes() {
+			referenced[hash] = true
+		}
+	}
+	for hash := range d.tracked {
+		if !referenced[hash] {
+			delete(d.tracked, hash)
+			delete(d.receipts, hash)
+		}
+	}
+}
+
+// Suspects returns a snapshot of all suspects, most recently seen first.
+func (d *MEVDetector) Suspects() []MEVSuspect {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	sorted := d.sorted()
+	suspects := make([]MEVSuspect, len(sorted))
+	for i, suspect := range sorted {
+		suspects[i] = *suspect
+	}
+	return suspects
+}
+
+func (d *MEVDetector) sorted() []*MEVSuspect {
+	suspects := make([]*MEVSuspect, 0, len(d.suspects))
+	for _, suspect := range d.suspects {
+		suspects = append(suspects, suspect)
+	}
+	slices.SortFunc(suspects, func(a, b *MEVSuspect) int {
+		if c := b.FirstSeen.Compare(a.FirstSeen); c != 0 {
+			return c
+		}
+		return strings.Compare(a.key(), b.key())
+	})
+	return suspects
+}
+
+// mevLine renders a suspect as a row of the MEV box, marked ✓ once the receipts confirm it, ✗ once they don't and ?
+// while it is pending.
+func mevLine(suspect MEVSuspect) string {
+	mark := "?"
+	switch suspect.Outcome {
+	case MEVOutcomeConfirmed:
+		mark = "✓"
+	case MEVOutcomeMissed:
+		mark = "✗"
+	}
+	return truncate(fmt.Sprintf("%s %s | victim %s | %s",
+		mark, suspect.Kind, shortenHash(suspect.Victim.Hex()), shortenHash(suspect.Target.Hex())), lineWidth)
+}
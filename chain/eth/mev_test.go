@@ -0,0 +1,84 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func mevTx(hash, from, to string, tip int64, input []byte) *RPCTransaction {
+	target := common.HexToAddress(to)
+	return &RPCTransaction{
+		Hash:      common.HexToHash(hash),
+		From:      common.HexToAddress(from),
+		To:        &target,
+		GasTipCap: (*hexutil.Big)(big.NewInt(tip)),
+		Input:     input,
+	}
+}
+
+func TestMEVDetectorSandwich(t *testing.T) {
+	front := mevTx("0x1", "0xb0", "0xaa", 30, []byte{1, 2, 3, 4})
+	victim := mevTx("0x2", "0xc0", "0xaa", 20, []byte{9, 9, 9, 9})
+	back := mevTx("0x3", "0xb0", "0xaa", 10, []byte{5, 6, 7, 8})
+	unrelated := mevTx("0x4", "0xd0", "0xbb", 5, []byte{1, 2, 3, 4})
+
+	d := NewMEVDetector()
+	d.Detect([]*RPCTransaction{victim, back, unrelated, front}, time.Now())
+
+	suspects := d.Suspects()
+	require.Len(t, suspects, 1)
+	require.Equal(t, MEVKindSandwich, suspects[0].Kind)
+	require.Equal(t, victim.Hash, suspects[0].Victim)
+	require.Equal(t, []common.Hash{front.Hash, back.Hash}, suspects[0].Attackers)
+	require.Equal(t, MEVOutcomePending, suspects[0].Outcome)
+
+	block := common.HexToHash("0xb10c")
+	receipt := func(index uint) *types.Receipt {
+		return &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockHash: block, BlockNumber: big.NewInt(1), TransactionIndex: index}
+	}
+	d.Resolve([]*Transaction{{Data: front, Receipt: receipt(0)}, {Data: victim, Receipt: receipt(1)}})
+	require.Equal(t, MEVOutcomePending, d.Suspects()[0].Outcome)
+
+	d.Resolve([]*Transaction{{Data: back, Receipt: receipt(2)}})
+	require.Equal(t, MEVOutcomeConfirmed, d.Suspects()[0].Outcome)
+}
+
+func TestMEVDetectorFrontrun(t *testing.T) {
+	calldata := []byte{1, 2, 3, 4, 5}
+	victim := mevTx("0x1", "0xc0", "0xaa", 10, calldata)
+	attacker := mevTx("0x2", "0xb0", "0xaa", 50, calldata)
+
+	d := NewMEVDetector()
+	d.Detect([]*RPCTransaction{victim, attacker}, time.Now())
+
+	suspects := d.Suspects()
+	require.Len(t, suspects, 1)
+	require.Equal(t, MEVKindFrontrun, suspects[0].Kind)
+	require.Equal(t, []common.Hash{attacker.Hash}, suspects[0].Attackers)
+
+	// the victim was dropped, so the frontrun can't be confirmed.
+	d.Resolve([]*Transaction{{Data: attacker, Receipt: &types.Receipt{Status: types.ReceiptStatusSuccessful}}, {Data: victim}})
+	require.Equal(t, MEVOutcomeMissed, d.Suspects()[0].Outcome)
+}
+
+func TestMEVLine(t *testing.T) {
+	suspect := MEVSuspect{
+		Kind:    MEVKindSandwich,
+		Target:  common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D"),
+		Victim:  common.HexToHash("0xab"),
+		Outcome: MEVOutcomeConfirmed,
+	}
+	line := mevLine(suspect)
+	require.Equal(t, "✓ sandwich | victim 0x0000...00ab | 0x7a25...488D", line)
+	require.LessOrEqual(t, lipgloss.Width(line), lineWidth)
+
+	suspect.Outcome = MEVOutcomePending
+	require.Equal(t, "?", mevLine(suspect)[:1])
+}
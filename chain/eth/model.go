@@ -4,12 +4,15 @@ import (
 	"cmp"
 	"context"
 	"fmt"
+	"math/big"
 	"slices"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/technicallyty/xray/chain"
 )
 
@@ -20,15 +23,35 @@ type EthModel struct {
 	completed    []*Transaction
 	name         string
 	pollingRate  time.Duration
+
+	// opStack is set when the chain has the OP Stack GasPriceOracle predeploy.
+	opStack bool
+	// privatePool is set when the node does not expose its mempool, e.g. an OP Stack sequencer.
+	privatePool bool
+	emptyPolls  int
+
+	mev *MEVDetector
+
+	simulation      SimulationConfig
+	simulationBlock string
+	// predicted holds the txs predicted to fail, most recent last.
+	predicted []*Transaction
+	// revertsCaught counts the simulated txs that were predicted to fail and reverted, out of revertsActual that
+	// reverted and revertsPredicted that were predicted to fail.
+	revertsCaught    int
+	revertsActual    int
+	revertsPredicted int
 }
 
-func NewEthModel(client *EthereumRPCClient, endpoint string, pollingRate time.Duration) *EthModel {
+func NewEthModel(client *EthereumRPCClient, endpoint string, pollingRate time.Duration, simulation SimulationConfig) *EthModel {
 	return &EthModel{
 		client:       client,
 		transactions: make(map[string][]*Transaction),
 		completed:    make([]*Transaction, 0),
 		name:         fmt.Sprintf("Ethereum - %s", endpoint),
 		pollingRate:  pollingRate,
+		mev:          NewMEVDetector(),
+		simulation:   simulation,
 	}
 }
 
@@ -45,6 +68,14 @@ var (
 			Width(60)
 )
 
+// lineWidth is the content width of a box, inside its padding.
+const lineWidth = 58
+
+// truncate cuts s to at most n terminal cells.
+func truncate(s string, n int) string {
+	return ansi.Truncate(s, n, "…")
+}
+
 func shortenHash(hash string) string {
 	if len(hash) <= 10 {
 		return hash
@@ -61,6 +92,29 @@ func formatGas(gas uint64) string {
 	return fmt.Sprintf("%d", gas)
 }
 
+// formatGwei formats a wei amount in gwei, scaled like formatGas.
+func formatGwei(wei *big.Int) string {
+	gwei, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.GWei)).Float64()
+	if gwei >= 1000000 {
+		return fmt.Sprintf("%.1fMgw", gwei/1000000)
+	} else if gwei >= 1000 {
+		return fmt.Sprintf("%.1fKgw", gwei/1000)
+	}
+	return fmt.Sprintf("%.2fgw", gwei)
+}
+
+// formatTx formats the columns shared by the pool and completed boxes.
+func (e *EthModel) formatTx(tx *Transaction) string {
+	line := fmt.Sprintf("%s | N:%d | G:%s",
+		shortenHash(tx.Data.Hash.Hex()), uint64(tx.Data.Nonce), formatGas(uint64(tx.Data.Gas)))
+	if tx.Data.IsDeposit() {
+		line += " | deposit"
+	} else if e.opStack && tx.L1Fee != nil {
+		line += " | L1:" + formatGwei(tx.L1Fee)
+	}
+	return line
+}
+
 func getStatusPrefix(status StatusType) string {
 	switch status {
 	case StatusTypeSuccess:
@@ -115,15 +169,24 @@ func (e *EthModel) Displays() []string {
 			displayTxs = txs[:maxTxsPerBox]
 		}
 
-		for _, tx := range displayTxs {
-			shortHash := shortenHash(tx.Data.Hash.Hex())
-			gasStr := formatGas(uint64(tx.Data.Gas))
+		if e.privatePool && len(txs) == 0 {
+			if e.opStack {
+				lines = append(lines, evictedStyle.Render("Sequencer mempool is private: txpool_content is"))
+				lines = append(lines, evictedStyle.Render("empty while blocks include transactions"))
+			} else {
+				lines = append(lines, evictedStyle.Render("Node does not expose txpool_content"))
+			}
+		}
 
-			line := fmt.Sprintf("%s | N:%d | G:%s",
-				shortHash, uint64(tx.Data.Nonce), gasStr)
+		for _, tx := range displayTxs {
+			line := e.formatTx(tx)
 
 			// Apply styling based on status
-			line = inMempoolStyle.Render(line)
+			if tx.Prediction != nil && tx.Prediction.Fails {
+				line = failedStyle.Render("✗ " + line + " | " + shortenReason(tx.Prediction.Reason))
+			} else {
+				line = inMempoolStyle.Render(line)
+			}
 			lines = append(lines, line)
 		}
 
@@ -152,12 +215,7 @@ func (e *EthModel) Displays() []string {
 
 		for i := start; i < len(completed); i++ {
 			tx := completed[i]
-			shortHash := shortenHash(tx.Data.Hash.Hex())
-			gasStr := formatGas(uint64(tx.Data.Gas))
-			prefix := getStatusPrefix(tx.Status)
-
-			line := fmt.Sprintf("%s%s | N:%d | G:%s",
-				prefix, shortHash, uint64(tx.Data.Nonce), gasStr)
+			line := getStatusPrefix(tx.Status) + e.formatTx(tx)
 
 			// Apply styling based on status
 			switch tx.Status {
@@ -184,6 +242,80 @@ func (e *EthModel) Displays() []string {
 		displays = append(displays, boxStyle.Render(content))
 	}
 
+	// display predicted failures and whether the receipts agreed
+	if e.simulation.Enabled {
+		predicted := make([]*Transaction, len(e.predicted))
+		copy(predicted, e.predicted)
+		slices.Reverse(predicted)
+		if len(predicted) > maxTxsPerBox {
+			predicted = predicted[:maxTxsPerBox]
+		}
+
+		var lines []string
+		lines = append(lines, fmt.Sprintf("Reverts caught %d/%d, predictions right %d/%d (%s)",
+			e.revertsCaught, e.revertsActual, e.revertsCaught, e.revertsPredicted, e.simulationBlock))
+		lines = append(lines, strings.Repeat("-", 50))
+
+		for _, tx := range predicted {
+			reason := tx.Prediction.Reason
+			if tx.Prediction.Trace != "" {
+				reason = tx.Prediction.Trace
+			}
+			line := fmt.Sprintf("%s | %s", shortenHash(tx.Data.Hash.Hex()), shortenReason(reason))
+
+			switch tx.Prediction.Actual {
+			case "":
+				line = inMempoolStyle.Render("? " + line)
+			case StatusTypeFailed:
+				line = failedStyle.Render("✓ " + line + " | reverted")
+			case StatusTypeSuccess:
+				line = successStyle.Render("✗ " + line + " | succeeded")
+			default:
+				line = evictedStyle.Render("⚠ " + line + " | " + string(tx.Prediction.Actual))
+			}
+			lines = append(lines, line)
+		}
+
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+
+		content := strings.Join(lines, "\n")
+		displays = append(displays, boxStyle.Render(content))
+	}
+
+	// display likely MEV setups in the pending pool
+	{
+		suspects := e.mev.Suspects()
+
+		var lines []string
+		lines = append(lines, fmt.Sprintf("MEV Suspects (%d)", len(suspects)))
+		lines = append(lines, strings.Repeat("-", 50))
+
+		if len(suspects) > maxTxsPerBox {
+			suspects = suspects[:maxTxsPerBox]
+		}
+		for _, suspect := range suspects {
+			line := mevLine(suspect)
+			switch suspect.Outcome {
+			case MEVOutcomeConfirmed:
+				line = failedStyle.Render(line)
+			case MEVOutcomeMissed:
+				line = successStyle.Render(line)
+			default:
+				line = evictedStyle.Render(line)
+			}
+			lines = append(lines, line)
+		}
+
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+
+		content := strings.Join(lines, "\n")
+		displays = append(displays, boxStyle.Render(content))
+	}
+
 	return displays
 }
 
@@ -193,19 +325,22 @@ func (e *EthModel) Name() string {
 
 func (e *EthModel) Start(ctx context.Context) {
 	go func() {
-		ticker := time.NewTicker(e.pollingRate)
-		defer ticker.Stop()
-		for range ticker.C {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
+		opStack, err := e.client.IsOPStack(ctx)
+		if err == nil {
+			e.opStack = opStack
+		}
+		if e.simulation.Enabled {
+			e.simulationBlock = e.client.SimulationBlock(ctx)
+		}
 
+		chain.Poll(ctx, e.pollingRate, func() {
 			// get tx pool contents.
 			res, err := e.client.TxPoolContent(ctx)
 			if err != nil {
-				continue
+				if isMethodNotFound(err) {
+					e.privatePool = true
+				}
+				return
 			}
 			txMap := res.ConvertToMap()
 			for poolName, txs := range txMap {
@@ -215,23 +350,17 @@ func (e *EthModel) Start(ctx context.Context) {
 				txMap[poolName] = txs
 			}
 
-			// create a set of current transaction hashes for fast lookup
-			currentTxHashes := make(map[string]bool)
-			for _, txs := range txMap {
-				for _, tx := range txs {
-					currentTxHashes[tx.Data.Hash.Hex()] = true
-				}
+			e.carryOver(txMap)
+			if e.opStack {
+				e.checkPrivatePool(ctx, txMap)
+				e.updateL1Fees(ctx, txMap)
+			}
+			if e.simulation.Enabled {
+				e.simulate(ctx, txMap["pending"])
 			}
 
 			// find all transactions in state that no longer exist
-			var removedTransactions []*Transaction
-			for _, txs := range e.transactions {
-				for _, tx := range txs {
-					if !currentTxHashes[tx.Data.Hash.Hex()] {
-						removedTransactions = append(removedTransactions, tx)
-					}
-				}
-			}
+			removedTransactions := chain.Removed(byHash(e.transactions), byHash(txMap))
 
 			// get receipts for removed transactions and update their status
 			if len(removedTransactions) > 0 {
@@ -260,26 +389,184 @@ func (e *EthModel) Start(ctx context.Context) {
 							// transaction failed
 							removedTransactions[i].Status = StatusTypeFailed
 						}
+						removedTransactions[i].Receipt = receipt
 					}
 				}
+				e.mev.Resolve(removedTransactions)
+				e.resolvePredictions(removedTransactions)
 				// append removed transactions to completed
 				e.completed = append(e.completed, removedTransactions...)
 				slices.SortFunc(e.completed, func(a, b *Transaction) int {
 					return cmp.Compare(a.Data.Gas, b.Data.Gas)
 				})
 
-				// Keep only the last 50 completed transactions
-				const maxCompleted = 50
-				if len(e.completed) > maxCompleted {
-					e.completed = e.completed[len(e.completed)-maxCompleted:]
-				}
+				e.completed = chain.TrimCompleted(e.completed)
 			}
 
+			pending := make([]*RPCTransaction, len(txMap["pending"]))
+			for i, tx := range txMap["pending"] {
+				pending[i] = tx.Data
+			}
+			e.mev.Detect(pending, time.Now())
+
 			// update state with new transactions
 			e.transactions = txMap
-		}
+		})
 	}()
+}
+
+// byHash indexes the transactions of every pool by hash.
+func byHash(txMap map[string][]*Transaction) map[string]*Transaction {
+	txs := make(map[string]*Transaction)
+	for _, pool := range txMap {
+		for _, tx := range pool {
+			txs[tx.Data.Hash.Hex()] = tx
+		}
+	}
+	return txs
+}
+
+// privatePoolEmptyPolls is the number of consecutive empty polls after which the latest block is checked
+// to tell an idle pool apart from one the sequencer does not expose.
+const privatePoolEmptyPolls = 20
+
+// checkPrivatePool detects an OP Stack sequencer that answers txpool_content with an empty pool
+// while it keeps producing blocks with user transactions.
+func (e *EthModel) checkPrivatePool(ctx context.Context, txMap map[string][]*Transaction) {
+	var count int
+	for _, txs := range txMap {
+		count += len(txs)
+	}
+	if count > 0 {
+		e.emptyPolls = 0
+		e.privatePool = false
+		return
+	}
+
+	e.emptyPolls++
+	if e.emptyPolls%privatePoolEmptyPolls != 0 {
+		return
+	}
+	txCount, err := e.client.LatestBlockTxCount(ctx)
+	if err != nil {
+		return
+	}
+	// every OP Stack block starts with the L1 attributes deposit, so anything beyond it came from users.
+	e.privatePool = txCount > 1
+}
+
+// carryOver copies what was already looked up for a tx in previous polls onto the freshly polled txs.
+func (e *EthModel) carryOver(txMap map[string][]*Transaction) {
+	previous := make(map[common.Hash]*Transaction)
+	for _, txs := range e.transactions {
+		for _, tx := range txs {
+			previous[tx.Data.Hash] = tx
+		}
+	}
+	for _, txs := range txMap {
+		for _, tx := range txs {
+			if prev, ok := previous[tx.Data.Hash]; ok {
+				tx.L1Fee = prev.L1Fee
+				tx.Prediction = prev.Prediction
+			}
+		}
+	}
+}
+
+// updateL1Fees looks up the L1 data fees of txs that don't have one yet.
+func (e *EthModel) updateL1Fees(ctx context.Context, txMap map[string][]*Transaction) {
+	var missing []*RPCTransaction
+	for _, txs := range txMap {
+		for _, tx := range txs {
+			if tx.L1Fee == nil && !tx.Data.IsDeposit() && len(missing) < maxL1FeeLookups {
+				missing = append(missing, tx.Data)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	fees, err := e.client.BatchL1Fees(ctx, missing)
+	if err != nil {
+		return
+	}
+	for _, txs := range txMap {
+		for _, tx := range txs {
+			if fee, ok := fees[tx.Data.Hash]; ok {
+				tx.L1Fee = fee
+			}
+		}
+	}
+}
+
+// simulate predicts the outcome of pending txs that have not been simulated yet.
+func (e *EthModel) simulate(ctx context.Context, pending []*Transaction) {
+	var toSimulate []*Transaction
+	for _, tx := range pending {
+		if tx.Prediction == nil && e.simulation.watches(tx.Data) && len(toSimulate) < maxSimulationsPerPoll {
+			toSimulate = append(toSimulate, tx)
+		}
+	}
+	if len(toSimulate) == 0 {
+		return
+	}
+
+	calls := make([]*RPCTransaction, len(toSimulate))
+	for i, tx := range toSimulate {
+		calls[i] = tx.Data
+	}
+	predictions, err := e.client.BatchSimulate(ctx, calls, e.simulationBlock)
+	if err != nil {
+		return
+	}
+
+	predicted := e.predicted
+	for i, prediction := range predictions {
+		if prediction == nil {
+			continue
+		}
+		if prediction.Fails && e.simulation.Trace {
+			if trace, err := e.client.TraceFailure(ctx, calls[i], e.simulationBlock); err == nil {
+				prediction.Trace = trace
+			}
+		}
+		toSimulate[i].Prediction = prediction
+		if prediction.Fails {
+			predicted = append(predicted, toSimulate[i])
+		}
+	}
+
+	// Keep only the last 50 predicted failures
+	const maxPredicted = 50
+	if len(predicted) > maxPredicted {
+		predicted = predicted[len(predicted)-maxPredicted:]
+	}
+	e.predicted = predicted
+}
 
+// resolvePredictions compares the predictions of txs that left the pool with their receipts. Only reverts are
+// counted, since most txs succeed and counting those would say little about how well failures are predicted.
+func (e *EthModel) resolvePredictions(removed []*Transaction) {
+	for _, tx := range removed {
+		if tx.Prediction == nil {
+			continue
+		}
+		tx.Prediction.Actual = tx.Status
+		if tx.Status != StatusTypeSuccess && tx.Status != StatusTypeFailed {
+			continue
+		}
+		reverted := tx.Status == StatusTypeFailed
+		if reverted {
+			e.revertsActual++
+		}
+		if tx.Prediction.Fails {
+			e.revertsPredicted++
+			if reverted {
+				e.revertsCaught++
+			}
+		}
+	}
 }
 
 var _ chain.MempoolXray = &EthModel{}
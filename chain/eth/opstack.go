@@ -0,0 +1,162 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// DepositTxType is the type of OP Stack deposit transactions, which are derived from L1 and pay no L1 data fee.
+const DepositTxType = 0x7E
+
+// GasPriceOracleAddress is the OP Stack predeploy that prices the L1 data fee of L2 transactions.
+var GasPriceOracleAddress = common.HexToAddress("0x420000000000000000000000000000000000000F")
+
+const gasPriceOracleABIJSON = `[{"inputs":[{"internalType":"bytes","name":"_data","type":"bytes"}],"name":"getL1Fee","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+
+var gasPriceOracleABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(gasPriceOracleABIJSON))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// maxL1FeeLookups bounds the number of eth_calls made to the GasPriceOracle per poll.
+const maxL1FeeLookups = 256
+
+// errMethodNotFound is the JSON-RPC error code returned for methods a node does not expose.
+const errMethodNotFound = -32601
+
+// IsDeposit reports whether the transaction is an OP Stack deposit transaction.
+func (tx *RPCTransaction) IsDeposit() bool {
+	return tx.Type == DepositTxType
+}
+
+// UnsignedBinary returns the unsigned EIP-2718 encoding of the transaction, which is what the GasPriceOracle prices.
+func (tx *RPCTransaction) UnsignedBinary() ([]byte, error) {
+	var inner types.TxData
+	switch tx.Type {
+	case types.LegacyTxType:
+		inner = &types.LegacyTx{
+			Nonce:    uint64(tx.Nonce),
+			GasPrice: (*big.Int)(tx.GasPrice),
+			Gas:      uint64(tx.Gas),
+			To:       tx.To,
+			Value:    (*big.Int)(tx.Value),
+			Data:     tx.Input,
+		}
+	case types.AccessListTxType:
+		inner = &types.AccessListTx{
+			ChainID:    (*big.Int)(tx.ChainID),
+			Nonce:      uint64(tx.Nonce),
+			GasPrice:   (*big.Int)(tx.GasPrice),
+			Gas:        uint64(tx.Gas),
+			To:         tx.To,
+			Value:      (*big.Int)(tx.Value),
+			Data:       tx.Input,
+			AccessList: tx.accessList(),
+		}
+	case types.DynamicFeeTxType:
+		inner = &types.DynamicFeeTx{
+			ChainID:    (*big.Int)(tx.ChainID),
+			Nonce:      uint64(tx.Nonce),
+			GasTipCap:  (*big.Int)(tx.GasTipCap),
+			GasFeeCap:  (*big.Int)(tx.GasFeeCap),
+			Gas:        uint64(tx.Gas),
+			To:         tx.To,
+			Value:      (*big.Int)(tx.Value),
+			Data:       tx.Input,
+			AccessList: tx.accessList(),
+		}
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %d", tx.Type)
+	}
+	return types.NewTx(inner).MarshalBinary()
+}
+
+func (tx *RPCTransaction) accessList() types.AccessList {
+	if tx.Accesses == nil {
+		return nil
+	}
+	return *tx.Accesses
+}
+
+// IsOPStack reports whether the chain has the OP Stack GasPriceOracle predeploy.
+func (c *EthereumRPCClient) IsOPStack(ctx context.Context) (bool, error) {
+	var code hexutil.Bytes
+	if err := c.client.CallContext(ctx, &code, "eth_getCode", GasPriceOracleAddress, "latest"); err != nil {
+		return false, err
+	}
+	return len(code) > 0, nil
+}
+
+// LatestBlockTxCount returns the number of transactions in the latest block.
+func (c *EthereumRPCClient) LatestBlockTxCount(ctx context.Context) (uint64, error) {
+	var count hexutil.Uint64
+	if err := c.client.CallContext(ctx, &count, "eth_getBlockTransactionCountByNumber", "latest"); err != nil {
+		return 0, err
+	}
+	return uint64(count), nil
+}
+
+// BatchL1Fees asks the GasPriceOracle for the L1 data fee of each transaction in a single batch call.
+// Transactions that cannot be priced, such as deposits, are missing from the result.
+func (c *EthereumRPCClient) BatchL1Fees(ctx context.Context, txs []*RPCTransaction) (map[common.Hash]*big.Int, error) {
+	var (
+		batchElems []rpc.BatchElem
+		hashes     []common.Hash
+	)
+	for _, tx := range txs {
+		if tx.IsDeposit() {
+			continue
+		}
+		bz, err := tx.UnsignedBinary()
+		if err != nil {
+			continue
+		}
+		data, err := gasPriceOracleABI.Pack("getL1Fee", bz)
+		if err != nil {
+			return nil, err
+		}
+		batchElems = append(batchElems, rpc.BatchElem{
+			Method: "eth_call",
+			Args: []interface{}{
+				map[string]interface{}{"to": GasPriceOracleAddress, "data": hexutil.Bytes(data)},
+				"latest",
+			},
+			Result: new(hexutil.Bytes),
+		})
+		hashes = append(hashes, tx.Hash)
+	}
+	if len(batchElems) == 0 {
+		return nil, nil
+	}
+
+	if err := c.client.BatchCallContext(ctx, batchElems); err != nil {
+		return nil, err
+	}
+
+	fees := make(map[common.Hash]*big.Int, len(batchElems))
+	for i, elem := range batchElems {
+		if elem.Error != nil {
+			continue
+		}
+		fees[hashes[i]] = new(big.Int).SetBytes(*elem.Result.(*hexutil.Bytes))
+	}
+	return fees, nil
+}
+
+// isMethodNotFound reports whether err is the node refusing an RPC method it does not expose.
+func isMethodNotFound(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == errMethodNotFound
+}
@@ -0,0 +1,41 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsignedBinary(t *testing.T) {
+	to := common.HexToAddress("0x1234")
+	rpcTx := &RPCTransaction{
+		Type:      types.DynamicFeeTxType,
+		ChainID:   (*hexutil.Big)(big.NewInt(10)),
+		Nonce:     7,
+		GasTipCap: (*hexutil.Big)(big.NewInt(1)),
+		GasFeeCap: (*hexutil.Big)(big.NewInt(2)),
+		Gas:       21000,
+		To:        &to,
+		Value:     (*hexutil.Big)(big.NewInt(3)),
+		Input:     []byte{0xde, 0xad},
+	}
+
+	bz, err := rpcTx.UnsignedBinary()
+	require.NoError(t, err)
+
+	var decoded types.Transaction
+	require.NoError(t, decoded.UnmarshalBinary(bz))
+	require.Equal(t, uint8(types.DynamicFeeTxType), decoded.Type())
+	require.Equal(t, uint64(7), decoded.Nonce())
+	require.Equal(t, &to, decoded.To())
+	require.Equal(t, []byte{0xde, 0xad}, decoded.Data())
+
+	deposit := &RPCTransaction{Type: DepositTxType}
+	require.True(t, deposit.IsDeposit())
+	_, err = deposit.UnsignedBinary()
+	require.Error(t, err)
+}
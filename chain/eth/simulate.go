@@ -0,0 +1,193 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxSimulationsPerPoll bounds the number of eth_calls made per poll.
+const maxSimulationsPerPoll = 64
+
+// SimulationConfig configures pre-inclusion failure prediction.
+type SimulationConfig struct {
+	Enabled bool
+	// Watch limits simulation to txs sent from or to one of these addresses. All pending txs are simulated if empty.
+	Watch []common.Address
+	// Trace runs debug_traceCall on predicted failures to find the call frame that reverted.
+	Trace bool
+}
+
+func (c SimulationConfig) watches(tx *RPCTransaction) bool {
+	if len(c.Watch) == 0 {
+		return true
+	}
+	for _, addr := range c.Watch {
+		if tx.From == addr || (tx.To != nil && *tx.To == addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Prediction is the simulated outcome of a pending tx.
+type Prediction struct {
+	Fails  bool
+	Reason string // decoded revert reason or the node's error
+	Trace  string // the reverting call frame, when tracing is enabled
+	// Actual is the status of the tx after it left the pool, empty while pending.
+	Actual StatusType
+}
+
+// callArgs converts a pending tx into eth_call arguments.
+func callArgs(tx *RPCTransaction) map[string]interface{} {
+	args := map[string]interface{}{
+		"from":  tx.From,
+		"gas":   tx.Gas,
+		"input": tx.Input,
+	}
+	if tx.To != nil {
+		args["to"] = tx.To
+	}
+	if tx.Value != nil {
+		args["value"] = tx.Value
+	}
+	if tx.GasFeeCap != nil {
+		args["maxFeePerGas"] = tx.GasFeeCap
+		args["maxPriorityFeePerGas"] = tx.GasTipCap
+	} else if tx.GasPrice != nil {
+		args["gasPrice"] = tx.GasPrice
+	}
+	if tx.Accesses != nil {
+		args["accessList"] = tx.Accesses
+	}
+	return args
+}
+
+// SimulationBlock returns the block tag pending txs should be simulated against: "pending" if the node supports it, "latest" otherwise.
+func (c *EthereumRPCClient) SimulationBlock(ctx context.Context) string {
+	var result hexutil.Bytes
+	err := c.client.CallContext(ctx, &result, "eth_call", map[string]interface{}{"to": common.Address{}}, "pending")
+	if err != nil {
+		return "latest"
+	}
+	return "pending"
+}
+
+// BatchSimulate runs eth_call for every tx in a single batch call against block.
+// A nil prediction means the simulation itself could not be run.
+func (c *EthereumRPCClient) BatchSimulate(ctx context.Context, txs []*RPCTransaction, block string) ([]*Prediction, error) {
+	if len(txs) == 0 {
+		return nil, nil
+	}
+
+	batchElems := make([]rpc.BatchElem, len(txs))
+	for i, tx := range txs {
+		batchElems[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args:   []interface{}{callArgs(tx), block},
+			Result: new(hexutil.Bytes),
+		}
+	}
+
+	if err := c.client.BatchCallContext(ctx, batchElems); err != nil {
+		return nil, err
+	}
+
+	predictions := make([]*Prediction, len(txs))
+	for i, elem := range batchElems {
+		predictions[i] = predictionFromError(elem.Error)
+	}
+	return predictions, nil
+}
+
+// revertErrorCode is the JSON-RPC error code geth returns for an eth_call that reverted.
+const revertErrorCode = 3
+
+// predictionFromError turns the error of an eth_call into a prediction.
+// Reverts yield a failure with the decoded revert reason. Any other error, such as a rate limit, an unknown block
+// or a transport error, means the simulation could not be run and yields nil.
+func predictionFromError(err error) *Prediction {
+	if err == nil {
+		return &Prediction{}
+	}
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return nil
+	}
+	if rpcErr.ErrorCode() != revertErrorCode && !strings.Contains(err.Error(), "execution reverted") {
+		return nil
+	}
+	prediction := &Prediction{Fails: true, Reason: err.Error()}
+
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if bz, decodeErr := hexutil.Decode(data); decodeErr == nil {
+				if reason, unpackErr := abi.UnpackRevert(bz); unpackErr == nil {
+					prediction.Reason = reason
+				}
+			}
+		}
+	}
+	return prediction
+}
+
+// callFrame is the output of the callTracer.
+type callFrame struct {
+	To           *common.Address `json:"to"`
+	Error        string          `json:"error"`
+	RevertReason string          `json:"revertReason"`
+	Calls        []callFrame     `json:"calls"`
+}
+
+// deepestFailure returns the innermost frame that failed, which is where the revert originated.
+func (f *callFrame) deepestFailure() *callFrame {
+	for i := range f.Calls {
+		if failed := f.Calls[i].deepestFailure(); failed != nil {
+			return failed
+		}
+	}
+	if f.Error != "" {
+		return f
+	}
+	return nil
+}
+
+// TraceFailure traces the call of a tx with the callTracer and describes the call frame that reverted.
+func (c *EthereumRPCClient) TraceFailure(ctx context.Context, tx *RPCTransaction, block string) (string, error) {
+	var frame callFrame
+	err := c.client.CallContext(ctx, &frame, "debug_traceCall", callArgs(tx), block, map[string]interface{}{"tracer": "callTracer"})
+	if err != nil {
+		return "", err
+	}
+
+	failed := frame.deepestFailure()
+	if failed == nil {
+		return "", nil
+	}
+	reason := failed.RevertReason
+	if reason == "" {
+		reason = failed.Error
+	}
+	if failed.To == nil {
+		return reason, nil
+	}
+	return fmt.Sprintf("%s: %s", shortenHash(failed.To.Hex()), reason), nil
+}
+
+// shortenReason keeps a revert reason short enough to share a line with the tx.
+func shortenReason(reason string) string {
+	reason = strings.TrimPrefix(reason, "execution reverted: ")
+	const maxLen = 22
+	if len(reason) > maxLen {
+		return reason[:maxLen-3] + "..."
+	}
+	return reason
+}
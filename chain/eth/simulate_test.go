@@ -0,0 +1,76 @@
+package eth
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+type revertError struct {
+	data string
+}
+
+func (e revertError) Error() string          { return "execution reverted" }
+func (e revertError) ErrorCode() int         { return 3 }
+func (e revertError) ErrorData() interface{} { return e.data }
+
+type rpcError struct {
+	code    int
+	message string
+}
+
+func (e rpcError) Error() string  { return e.message }
+func (e rpcError) ErrorCode() int { return e.code }
+
+func TestPredictionFromError(t *testing.T) {
+	require.Equal(t, &Prediction{}, predictionFromError(nil))
+	require.Nil(t, predictionFromError(errors.New("connection refused")))
+	// the simulation did not run, which says nothing about the tx
+	require.Nil(t, predictionFromError(rpcError{code: -32005, message: "limit exceeded"}))
+	require.Nil(t, predictionFromError(rpcError{code: -32000, message: "header not found"}))
+	require.Nil(t, predictionFromError(rpcError{code: -32601, message: "the method eth_call does not exist"}))
+	require.Equal(t, &Prediction{Fails: true, Reason: "execution reverted"},
+		predictionFromError(rpcError{code: -32000, message: "execution reverted"}))
+
+	// Error(string) with reason "nope"
+	data := append([]byte{0x08, 0xc3, 0x79, 0xa0}, common.LeftPadBytes(big.NewInt(32).Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(big.NewInt(4).Bytes(), 32)...)
+	data = append(data, common.RightPadBytes([]byte("nope"), 32)...)
+
+	prediction := predictionFromError(revertError{data: hexutil.Encode(data)})
+	require.Equal(t, &Prediction{Fails: true, Reason: "nope"}, prediction)
+}
+
+func TestDeepestFailure(t *testing.T) {
+	inner := common.HexToAddress("0x02")
+	frame := callFrame{
+		Error: "execution reverted",
+		Calls: []callFrame{
+			{To: &inner},
+			{To: &inner, Error: "execution reverted", RevertReason: "STF"},
+		},
+	}
+	failed := frame.deepestFailure()
+	require.NotNil(t, failed)
+	require.Equal(t, "STF", failed.RevertReason)
+	require.Nil(t, (&callFrame{}).deepestFailure())
+}
+
+func TestResolvePredictions(t *testing.T) {
+	e := &EthModel{}
+	e.resolvePredictions([]*Transaction{
+		{Status: StatusTypeFailed, Prediction: &Prediction{Fails: true}},
+		{Status: StatusTypeFailed, Prediction: &Prediction{}},
+		{Status: StatusTypeSuccess, Prediction: &Prediction{Fails: true}},
+		// successes predicted to succeed say nothing about reverts
+		{Status: StatusTypeSuccess, Prediction: &Prediction{}},
+		{Status: StatusTypeFailed},
+	})
+	require.Equal(t, 1, e.revertsCaught)
+	require.Equal(t, 2, e.revertsActual)
+	require.Equal(t, 2, e.revertsPredicted)
+}
@@ -4,20 +4,39 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math/big"
 	"strings"
+	"sync"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/technicallyty/xray/chain"
 )
 
+// senderWindowSize is the number of most recent transactions the top senders summary is computed over.
+const senderWindowSize = 1000
+
+// PendingTransaction is a transaction received from the subscription along with its recovered sender.
+type PendingTransaction struct {
+	Tx   *types.Transaction
+	From *common.Address // nil when the sender could not be recovered
+}
+
 type SubModel struct {
 	client     *rpc.Client
 	name       string
-	txs        []*types.Transaction
+	txs        []*PendingTransaction
 	maxDisplay int
+	signer     types.Signer
+	senders    *senderWindow
+
+	mu sync.RWMutex
 }
 
 var _ chain.MempoolXray = &SubModel{}
@@ -27,11 +46,25 @@ func NewRPCClient(url string) (*rpc.Client, error) {
 }
 
 func NewSubModel(client *rpc.Client, name string, maxDisplay int) *SubModel {
-	return &SubModel{client, name, make([]*types.Transaction, 0, maxDisplay), maxDisplay}
+	return &SubModel{
+		client:     client,
+		name:       name,
+		txs:        make([]*PendingTransaction, 0, maxDisplay),
+		maxDisplay: maxDisplay,
+		senders:    newSenderWindow(senderWindowSize),
+	}
 }
 
 func (s *SubModel) Start(ctx context.Context) {
 	go func() {
+		// detect the chain ID so senders can be recovered with the chain's signer.
+		chainID, err := ethclient.NewClient(s.client).ChainID(ctx)
+		if err != nil {
+			log.Println("ethereum sub: failed to detect chain id:", err)
+		} else {
+			s.signer = types.LatestSignerForChainID(chainID)
+		}
+
 		subscriber := gethclient.New(s.client)
 		txChannel := make(chan *types.Transaction, 1000)
 		sub, err := subscriber.SubscribeFullPendingTransactions(ctx, txChannel)
@@ -53,12 +86,24 @@ func (s *SubModel) Start(ctx context.Context) {
 					return
 				}
 
-				s.txs = append([]*types.Transaction{tx}, s.txs...)
+				pending := &PendingTransaction{Tx: tx}
+				if s.signer != nil {
+					if from, err := types.Sender(s.signer, tx); err == nil {
+						pending.From = &from
+					}
+				}
+
+				s.mu.Lock()
+				s.txs = append([]*PendingTransaction{pending}, s.txs...)
 
 				// trim to maxDisplay size
 				if len(s.txs) > s.maxDisplay {
 					s.txs = s.txs[:s.maxDisplay]
 				}
+				if pending.From != nil {
+					s.senders.add(*pending.From)
+				}
+				s.mu.Unlock()
 			}
 		}
 	}()
@@ -76,24 +121,18 @@ var (
 func (s *SubModel) Displays() []string {
 	var displays []string
 
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	txs := s.txs
 
 	var lines []string
-	lines = append(lines, fmt.Sprintf("Pending Transactions"))
+	lines = append(lines, fmt.Sprintf("Pending Transactions (value in ether)"))
 	lines = append(lines, strings.Repeat("-", 50))
 
 	for _, tx := range txs {
-		shortHash := shortenHash(tx.Hash().Hex())
-		gasStr := formatGas(tx.Gas())
-
-		line := fmt.Sprintf(
-			"%s | N:%d | G:%s",
-			shortHash, tx.Nonce(), gasStr,
-		)
-
 		// Apply styling based on status
-		line = inMempoolStyle.Render(line)
-		lines = append(lines, line)
+		lines = append(lines, inMempoolStyle.Render(txLine(tx)))
 	}
 
 	// Fill remaining lines to maintain consistent box height
@@ -104,6 +143,26 @@ func (s *SubModel) Displays() []string {
 	content := strings.Join(lines, "\n")
 	displays = append(displays, boxStyle.Render(content))
 
+	// display the senders with the most transactions in the rolling window
+	{
+		const maxSenders = 8
+		var lines []string
+		lines = append(lines, fmt.Sprintf("Top Senders (last %d txs)", s.senders.len()))
+		lines = append(lines, strings.Repeat("-", 50))
+
+		for _, sender := range s.senders.top(maxSenders) {
+			line := fmt.Sprintf("%s | %d", sender.Address.Hex(), sender.Count)
+			lines = append(lines, inMempoolStyle.Render(line))
+		}
+
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+
+		content := strings.Join(lines, "\n")
+		displays = append(displays, boxStyle.Render(content))
+	}
+
 	return displays
 }
 
@@ -111,11 +170,35 @@ func (s *SubModel) Name() string {
 	return s.name
 }
 
+// lineWidth is the content width of a box, inside its padding.
+const lineWidth = 58
+
+// txLine renders a pending transaction as a row of the pending box, ending with its nonce, gas limit and type.
+func txLine(tx *PendingTransaction) string {
+	from := "unknown"
+	if tx.From != nil {
+		from = shortenAddress(*tx.From)
+	}
+	to := "[create]"
+	if tx.Tx.To() != nil {
+		to = shortenAddress(*tx.Tx.To())
+	}
+	line := fmt.Sprintf("%s | %s→%s | %s | N%d %s T%d",
+		shortenHash(tx.Tx.Hash().Hex()), from, to, formatValue(tx.Tx.Value()), tx.Tx.Nonce(), formatGas(tx.Tx.Gas()), tx.Tx.Type())
+	return ansi.Truncate(line, lineWidth, "…")
+}
+
+// shortenHash keeps the first 4 bytes of a hash, as block explorers do.
 func shortenHash(hash string) string {
 	if len(hash) <= 10 {
 		return hash
 	}
-	return hash[:6] + "..." + hash[len(hash)-4:]
+	return hash[:10]
+}
+
+func shortenAddress(addr common.Address) string {
+	hex := addr.Hex()
+	return hex[:4] + ".." + hex[len(hex)-4:]
 }
 
 func formatGas(gas uint64) string {
@@ -126,3 +209,18 @@ func formatGas(gas uint64) string {
 	}
 	return fmt.Sprintf("%d", gas)
 }
+
+// formatValue formats a wei amount in ether, keeping the result short enough for a table column.
+func formatValue(wei *big.Int) string {
+	if wei == nil || wei.Sign() == 0 {
+		return "0"
+	}
+	ether, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.Ether)).Float64()
+	if ether < 0.0001 {
+		return "<0.0001"
+	}
+	if ether >= 1000 {
+		return fmt.Sprintf("%.0f", ether)
+	}
+	return fmt.Sprintf("%.4g", ether)
+}
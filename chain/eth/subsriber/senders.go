@@ -0,0 +1,72 @@
+package subscriber
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SenderCount is the number of transactions a sender has in the rolling window.
+type SenderCount struct {
+	Address common.Address
+	Count   int
+}
+
+// senderWindow counts the senders of the last size transactions.
+type senderWindow struct {
+	ring   []common.Address
+	next   int
+	full   bool
+	counts map[common.Address]int
+}
+
+func newSenderWindow(size int) *senderWindow {
+	return &senderWindow{
+		ring:   make([]common.Address, size),
+		counts: make(map[common.Address]int),
+	}
+}
+
+// add records a transaction from sender, evicting the oldest entry once the window is full.
+func (w *senderWindow) add(sender common.Address) {
+	if w.full {
+		oldest := w.ring[w.next]
+		w.counts[oldest]--
+		if w.counts[oldest] == 0 {
+			delete(w.counts, oldest)
+		}
+	}
+	w.ring[w.next] = sender
+	w.counts[sender]++
+	w.next = (w.next + 1) % len(w.ring)
+	if w.next == 0 {
+		w.full = true
+	}
+}
+
+// len returns the number of transactions currently in the window.
+func (w *senderWindow) len() int {
+	if w.full {
+		return len(w.ring)
+	}
+	return w.next
+}
+
+// top returns up to n senders ordered by transaction count, highest first.
+func (w *senderWindow) top(n int) []SenderCount {
+	senders := make([]SenderCount, 0, len(w.counts))
+	for addr, count := range w.counts {
+		senders = append(senders, SenderCount{Address: addr, Count: count})
+	}
+	slices.SortFunc(senders, func(a, b SenderCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return a.Address.Cmp(b.Address)
+	})
+	if len(senders) > n {
+		senders = senders[:n]
+	}
+	return senders
+}
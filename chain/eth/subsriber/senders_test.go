@@ -0,0 +1,49 @@
+package subscriber
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSenderWindow(t *testing.T) {
+	a := common.HexToAddress("0x01")
+	b := common.HexToAddress("0x02")
+	c := common.HexToAddress("0x03")
+
+	w := newSenderWindow(3)
+	w.add(a)
+	w.add(a)
+	w.add(b)
+	require.Equal(t, 3, w.len())
+	require.Equal(t, []SenderCount{{a, 2}, {b, 1}}, w.top(5))
+
+	// the oldest entry for a falls out of the window.
+	w.add(c)
+	w.add(c)
+	require.Equal(t, 3, w.len())
+	require.Equal(t, []SenderCount{{c, 2}, {b, 1}}, w.top(5))
+	require.Equal(t, []SenderCount{{c, 2}}, w.top(1))
+}
+
+func TestTxLine(t *testing.T) {
+	from := common.HexToAddress("0x1111111111111111111111111111111111112222")
+	to := common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		Nonce: 42,
+		To:    &to,
+		Value: big.NewInt(params.Ether / 20),
+		Gas:   21000,
+	})
+	line := txLine(&PendingTransaction{Tx: tx, From: &from})
+	require.Equal(t, tx.Hash().Hex()[:10]+" | 0x11..2222→0x7a..488D | 0.05 | N42 21.0K T2", line)
+	require.LessOrEqual(t, lipgloss.Width(line), lineWidth)
+
+	create := types.NewTx(&types.LegacyTx{Nonce: 1234567, Value: new(big.Int).Mul(big.NewInt(1234), big.NewInt(params.Ether)), Gas: 12_345_678})
+	require.LessOrEqual(t, lipgloss.Width(txLine(&PendingTransaction{Tx: create})), lineWidth)
+}
@@ -0,0 +1,92 @@
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// HashPlaceholder is replaced by the tx hash in the params of the status lookup.
+const HashPlaceholder = "{hash}"
+
+// GenericRPCClient calls arbitrary JSON-RPC methods, returning their results as decoded JSON.
+type GenericRPCClient struct {
+	client *rpc.Client
+}
+
+// NewGenericRPCClient creates a client over http or websocket.
+func NewGenericRPCClient(address string) (*GenericRPCClient, error) {
+	client, err := rpc.Dial(address)
+	if err != nil {
+		return nil, err
+	}
+	return &GenericRPCClient{client: client}, nil
+}
+
+// Call calls method with params.
+func (c *GenericRPCClient) Call(ctx context.Context, method string, params []interface{}) (interface{}, error) {
+	var result json.RawMessage
+	if err := c.client.CallContext(ctx, &result, method, params...); err != nil {
+		return nil, err
+	}
+	return decodeJSON(result)
+}
+
+// StatusResult is the result of a status lookup. Err is set if that lookup failed.
+type StatusResult struct {
+	Result interface{}
+	Err    error
+}
+
+// LookupStatus calls method once per hash in a single batch, with HashPlaceholder in params replaced by the hash.
+// An error is only returned if the batch as a whole failed.
+func (c *GenericRPCClient) LookupStatus(ctx context.Context, method string, params []interface{}, hashes []string) ([]StatusResult, error) {
+	raws := make([]json.RawMessage, len(hashes))
+	batch := make([]rpc.BatchElem, len(hashes))
+	for i, hash := range hashes {
+		batch[i] = rpc.BatchElem{
+			Method: method,
+			Args:   substituteHash(params, hash).([]interface{}),
+			Result: &raws[i],
+		}
+	}
+	if err := c.client.BatchCallContext(ctx, batch); err != nil {
+		return nil, err
+	}
+	results := make([]StatusResult, len(hashes))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			results[i].Err = elem.Error
+			continue
+		}
+		if len(raws[i]) == 0 {
+			continue
+		}
+		results[i].Result, results[i].Err = decodeJSON(raws[i])
+	}
+	return results, nil
+}
+
+// substituteHash replaces HashPlaceholder in the strings of v, descending into arrays and tables.
+func substituteHash(v interface{}, hash string) interface{} {
+	switch v := v.(type) {
+	case string:
+		return strings.ReplaceAll(v, HashPlaceholder, hash)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, elem := range v {
+			out[i] = substituteHash(elem, hash)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			out[key] = substituteHash(elem, hash)
+		}
+		return out
+	default:
+		return v
+	}
+}
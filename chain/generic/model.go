@@ -0,0 +1,337 @@
+package generic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/technicallyty/xray/chain"
+)
+
+type StatusType string
+
+const (
+	StatusTypePending StatusType = "pending"
+	// StatusTypeLeft is the status of txs that left the mempool when no status lookup is configured.
+	StatusTypeLeft     StatusType = "left"
+	StatusTypeIncluded StatusType = "included"
+	StatusTypeSuccess  StatusType = "success"
+	StatusTypeFailed   StatusType = "failed"
+	StatusTypeDropped  StatusType = "dropped"
+)
+
+type Transaction struct {
+	Hash string
+	// Fields are the values of the configured display fields, in order.
+	Fields        []string
+	Status        StatusType
+	FirstSeen     time.Time
+	TimeCompleted time.Time
+}
+
+// Field is a value shown for every tx, selected relative to the tx.
+type Field struct {
+	Name string
+	Path string
+}
+
+// Config describes how to read a mempool over JSON-RPC. Paths are JSONPath-style selectors, see ParseSelector.
+type Config struct {
+	// Name is shown as the title of the view, the method and endpoint if unset.
+	Name string
+	// Method lists the mempool, called with Params.
+	Method string
+	Params []interface{}
+	// Txs selects the txs from the result of Method, Hash the hash relative to a tx.
+	// An empty Hash uses the tx itself, for methods that list hashes.
+	Txs  string
+	Hash string
+	// Fields are shown after the hash.
+	Fields []Field
+	// StatusMethod, if set, is called for every tx that left the mempool, with HashPlaceholder in StatusParams replaced
+	// by its hash. A null result or an error means the tx was dropped.
+	StatusMethod string
+	StatusParams []interface{}
+	// StatusPath selects the outcome from the status result, which succeeded if it is one of SuccessValues.
+	// Any result counts as included if unset.
+	StatusPath    string
+	SuccessValues []string
+}
+
+type field struct {
+	name     string
+	selector Selector
+}
+
+type GenericModel struct {
+	client       *GenericRPCClient
+	cfg          Config
+	txs          Selector
+	hash         Selector
+	fields       []field
+	status       Selector
+	transactions map[string]*Transaction // hash -> transaction
+	completed    []*Transaction
+	name         string
+	pollingRate  time.Duration
+
+	// lastErr is the error of the last poll, unhashed the number of txs without a hash in the last poll.
+	lastErr  error
+	unhashed int
+}
+
+// NewGenericModel creates a model from cfg, failing if cfg is incomplete or has an invalid path.
+func NewGenericModel(client *GenericRPCClient, endpoint string, pollingRate time.Duration, cfg Config) (*GenericModel, error) {
+	if cfg.Method == "" {
+		return nil, errors.New("method is required")
+	}
+	if cfg.StatusPath != "" && cfg.StatusMethod == "" {
+		return nil, errors.New("status_path requires status_method")
+	}
+	if cfg.StatusPath != "" && len(cfg.SuccessValues) == 0 {
+		return nil, errors.New("status_path requires success_values")
+	}
+	g := &GenericModel{
+		client:       client,
+		cfg:          cfg,
+		transactions: make(map[string]*Transaction),
+		completed:    make([]*Transaction, 0),
+		name:         cfg.Name,
+		pollingRate:  pollingRate,
+	}
+	if g.name == "" {
+		g.name = fmt.Sprintf("%s - %s", cfg.Method, endpoint)
+	}
+	var err error
+	if g.txs, err = ParseSelector(cfg.Txs); err != nil {
+		return nil, fmt.Errorf("txs: %w", err)
+	}
+	if g.hash, err = ParseSelector(cfg.Hash); err != nil {
+		return nil, fmt.Errorf("hash: %w", err)
+	}
+	if g.status, err = ParseSelector(cfg.StatusPath); err != nil {
+		return nil, fmt.Errorf("status_path: %w", err)
+	}
+	for _, f := range cfg.Fields {
+		selector, err := ParseSelector(f.Path)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		g.fields = append(g.fields, field{name: f.Name, selector: selector})
+	}
+	return g, nil
+}
+
+var (
+	// Styles for generic transactions
+	pendingStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("33"))              // bright blue
+	successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))              // bright green
+	failedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))             // bright red
+	droppedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("208"))             // orange
+	fadedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Faint(true) // dim gray and faded
+
+	boxStyle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder()).
+			Padding(0, 1).
+			Width(60)
+)
+
+// lineWidth is the width available to a row inside a box.
+const lineWidth = 58
+
+// truncate shortens s to at most n terminal cells.
+func truncate(s string, n int) string {
+	return ansi.Truncate(s, n, "…")
+}
+
+func shortenHash(hash string) string {
+	if len(hash) <= 10 {
+		return hash
+	}
+	return hash[:6] + "..." + hash[len(hash)-4:]
+}
+
+func getStatusPrefix(status StatusType) string {
+	switch status {
+	case StatusTypeIncluded, StatusTypeSuccess:
+		return "✓ "
+	case StatusTypeFailed:
+		return "✗ "
+	case StatusTypeDropped:
+		return "⚠ "
+	default:
+		return ""
+	}
+}
+
+func (g *GenericModel) Start(ctx context.Context) {
+	go chain.Poll(ctx, g.pollingRate, func() {
+		result, err := g.client.Call(ctx, g.cfg.Method, g.cfg.Params)
+		if err != nil {
+			g.lastErr = err
+			return
+		}
+		g.lastErr = nil
+
+		current := g.readTxs(result, time.Now())
+		if removed := chain.Removed(g.transactions, current); len(removed) > 0 {
+			g.settle(ctx, removed, time.Now())
+			g.completed = chain.AppendCompleted(g.completed, removed...)
+		}
+		g.transactions = current
+	})
+}
+
+// readTxs selects the txs of a mempool listing, keeping the txs already seen.
+func (g *GenericModel) readTxs(result interface{}, now time.Time) map[string]*Transaction {
+	current := make(map[string]*Transaction)
+	g.unhashed = 0
+	for _, raw := range g.txs.Select(result) {
+		hashes := g.hash.Select(raw)
+		if len(hashes) == 0 || formatValue(hashes[0]) == "" {
+			g.unhashed++
+			continue
+		}
+		hash := formatValue(hashes[0])
+		if prev, ok := g.transactions[hash]; ok {
+			current[hash] = prev
+			continue
+		}
+		tx := &Transaction{Hash: hash, Status: StatusTypePending, FirstSeen: now}
+		for _, f := range g.fields {
+			var values []string
+			for _, v := range f.selector.Select(raw) {
+				values = append(values, formatValue(v))
+			}
+			tx.Fields = append(tx.Fields, strings.Join(values, ","))
+		}
+		current[hash] = tx
+	}
+	return current
+}
+
+// settle looks up the status of txs that left the mempool. Without a status method they are only marked as left.
+func (g *GenericModel) settle(ctx context.Context, removed []*Transaction, now time.Time) {
+	for _, tx := range removed {
+		tx.TimeCompleted = now
+		tx.Status = StatusTypeLeft
+	}
+	if g.cfg.StatusMethod == "" {
+		return
+	}
+	hashes := make([]string, len(removed))
+	for i, tx := range removed {
+		hashes[i] = tx.Hash
+	}
+	results, err := g.client.LookupStatus(ctx, g.cfg.StatusMethod, g.cfg.StatusParams, hashes)
+	if err != nil {
+		return
+	}
+	for i, tx := range removed {
+		tx.Status = g.statusOf(results[i])
+	}
+}
+
+// statusOf maps the result of a status lookup to a status.
+func (g *GenericModel) statusOf(res StatusResult) StatusType {
+	if res.Err != nil || res.Result == nil {
+		return StatusTypeDropped
+	}
+	if g.cfg.StatusPath == "" {
+		return StatusTypeIncluded
+	}
+	for _, v := range g.status.Select(res.Result) {
+		if slices.Contains(g.cfg.SuccessValues, formatValue(v)) {
+			return StatusTypeSuccess
+		}
+	}
+	return StatusTypeFailed
+}
+
+// txLine renders the hash and display fields of a tx, shortening long values such as addresses.
+func (g *GenericModel) txLine(tx *Transaction) string {
+	parts := []string{shortenHash(tx.Hash)}
+	for i, value := range tx.Fields {
+		if len(value) > 14 {
+			value = shortenHash(value)
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", g.fields[i].name, value))
+	}
+	return strings.Join(parts, " | ")
+}
+
+func (g *GenericModel) Displays() []string {
+	var displays []string
+	const maxTxsPerBox = 8 // leave 2 lines for header and separator
+
+	// MEMPOOL UI
+	{
+		var txs []*Transaction
+		for _, tx := range g.transactions {
+			txs = append(txs, tx)
+		}
+		// oldest first, the listing order of the node is not kept
+		slices.SortFunc(txs, func(a, b *Transaction) int {
+			if order := a.FirstSeen.Compare(b.FirstSeen); order != 0 {
+				return order
+			}
+			return strings.Compare(a.Hash, b.Hash)
+		})
+
+		header := fmt.Sprintf("Mempool (%d txs", len(txs))
+		if g.unhashed > 0 {
+			header += fmt.Sprintf(", %d without hash", g.unhashed)
+		}
+		lines := []string{truncate(header+")", lineWidth), strings.Repeat("-", 50)}
+		if g.lastErr != nil {
+			lines = append(lines, failedStyle.Render(truncate("⚠ "+strings.ReplaceAll(g.lastErr.Error(), "\n", " "), lineWidth)))
+		}
+		for _, tx := range txs[:min(len(txs), maxTxsPerBox+2-len(lines))] {
+			lines = append(lines, pendingStyle.Render(truncate(g.txLine(tx), lineWidth)))
+		}
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+		displays = append(displays, boxStyle.Render(strings.Join(lines, "\n")))
+	}
+
+	// COMPLETED UI
+	{
+		completed := make([]*Transaction, len(g.completed))
+		copy(completed, g.completed)
+		slices.Reverse(completed)
+
+		lines := []string{"Completed", strings.Repeat("-", 50)}
+		for _, tx := range completed[:min(len(completed), maxTxsPerBox)] {
+			line := truncate(getStatusPrefix(tx.Status)+g.txLine(tx), lineWidth)
+			switch tx.Status {
+			case StatusTypeIncluded, StatusTypeSuccess:
+				line = successStyle.Render(line)
+			case StatusTypeFailed:
+				line = failedStyle.Render(line)
+			case StatusTypeDropped:
+				line = droppedStyle.Render(line)
+			default:
+				line = fadedStyle.Render(line)
+			}
+			lines = append(lines, line)
+		}
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+		displays = append(displays, boxStyle.Render(strings.Join(lines, "\n")))
+	}
+
+	return displays
+}
+
+func (g *GenericModel) Name() string {
+	return g.name
+}
+
+var _ chain.MempoolXray = &GenericModel{}
@@ -0,0 +1,156 @@
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNode answers txpool_content with its pending txs and eth_getTransactionReceipt with its receipts.
+type fakeNode struct {
+	pending  map[string]map[string]map[string]string
+	receipts map[string]map[string]string
+}
+
+func (n *fakeNode) serve(t *testing.T) *httptest.Server {
+	type request struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params []interface{}   `json:"params"`
+	}
+	answer := func(req request) map[string]interface{} {
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch req.Method {
+		case "txpool_content":
+			resp["result"] = map[string]interface{}{"pending": n.pending, "queued": map[string]interface{}{}}
+		case "eth_getTransactionReceipt":
+			if receipt, ok := n.receipts[req.Params[0].(string)]; ok {
+				resp["result"] = receipt
+			} else {
+				resp["result"] = nil
+			}
+		default:
+			resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		return resp
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var raw json.RawMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&raw))
+		w.Header().Set("Content-Type", "application/json")
+		if raw[0] == '[' {
+			var reqs []request
+			require.NoError(t, json.Unmarshal(raw, &reqs))
+			var resps []map[string]interface{}
+			for _, req := range reqs {
+				resps = append(resps, answer(req))
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(resps))
+			return
+		}
+		var req request
+		require.NoError(t, json.Unmarshal(raw, &req))
+		require.NoError(t, json.NewEncoder(w).Encode(answer(req)))
+	}))
+}
+
+func TestGenericModel(t *testing.T) {
+	node := &fakeNode{
+		pending: map[string]map[string]map[string]string{
+			"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa": {
+				"0": {"hash": "0x01", "nonce": "0x0"},
+				"1": {"hash": "0x02", "nonce": "0x1"},
+				"2": {"hash": "0x03", "nonce": "0x2"},
+				"3": {"nonce": "0x3"},
+			},
+		},
+		receipts: map[string]map[string]string{
+			"0x01": {"status": "0x1"},
+			"0x02": {"status": "0x0"},
+		},
+	}
+	srv := node.serve(t)
+	defer srv.Close()
+
+	client, err := NewGenericRPCClient(srv.URL)
+	require.NoError(t, err)
+	g, err := NewGenericModel(client, srv.URL, time.Second, Config{
+		Method:        "txpool_content",
+		Txs:           "$.pending.*.*",
+		Hash:          "hash",
+		Fields:        []Field{{Name: "N", Path: "nonce"}},
+		StatusMethod:  "eth_getTransactionReceipt",
+		StatusParams:  []interface{}{HashPlaceholder},
+		StatusPath:    "status",
+		SuccessValues: []string{"0x1"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "txpool_content - "+srv.URL, g.Name())
+
+	ctx := context.Background()
+	result, err := client.Call(ctx, g.cfg.Method, g.cfg.Params)
+	require.NoError(t, err)
+	g.transactions = g.readTxs(result, time.Now())
+	require.Len(t, g.transactions, 3)
+	require.Equal(t, 1, g.unhashed)
+	require.Equal(t, []string{"0x1"}, g.transactions["0x02"].Fields)
+	require.Equal(t, "0x02 | N 0x1", g.txLine(g.transactions["0x02"]))
+
+	// 0x01 succeeded, 0x02 failed and 0x03 was dropped
+	removed := []*Transaction{g.transactions["0x01"], g.transactions["0x02"], g.transactions["0x03"]}
+	g.settle(ctx, removed, time.Now())
+	require.Equal(t, StatusTypeSuccess, removed[0].Status)
+	require.Equal(t, StatusTypeFailed, removed[1].Status)
+	require.Equal(t, StatusTypeDropped, removed[2].Status)
+
+	// a long error takes a single row, leaving the rest of the box to the txs
+	g.lastErr = errors.New(strings.Repeat("connection reset by peer\n", 10))
+	mempool := g.Displays()[0]
+	require.Contains(t, mempool, "…")
+	require.Len(t, strings.Split(mempool, "\n"), 12)
+}
+
+func TestDisplaysWidth(t *testing.T) {
+	g, err := NewGenericModel(nil, "http://localhost:8545", time.Second, Config{
+		Method: "txpool_content",
+		Txs:    "$.pending.*.*",
+		Hash:   "hash",
+		Fields: []Field{{Name: "from", Path: "from"}, {Name: "nonce", Path: "nonce"}, {Name: "gas", Path: "gasPrice"}},
+	})
+	require.NoError(t, err)
+	tx := &Transaction{
+		Hash:   "0x" + strings.Repeat("ab", 32),
+		Fields: []string{"0x" + strings.Repeat("cd", 20), "0x1234", "0x2540be400"},
+		Status: StatusTypeSuccess,
+	}
+	g.transactions = map[string]*Transaction{tx.Hash: tx}
+	g.completed = append(g.completed, tx)
+	for _, display := range g.Displays() {
+		// 10 lines and the border, no row wrapped
+		require.Len(t, strings.Split(display, "\n"), 12)
+	}
+}
+
+func TestNewGenericModelConfig(t *testing.T) {
+	_, err := NewGenericModel(nil, "", time.Second, Config{})
+	require.Error(t, err)
+	_, err = NewGenericModel(nil, "", time.Second, Config{Method: "m", Txs: "a["})
+	require.Error(t, err)
+	_, err = NewGenericModel(nil, "", time.Second, Config{Method: "m", StatusMethod: "s", StatusPath: "status"})
+	require.Error(t, err)
+
+	// without a status method txs are only known to have left
+	g, err := NewGenericModel(nil, "", time.Second, Config{Name: "pool", Method: "m"})
+	require.NoError(t, err)
+	require.Equal(t, "pool", g.Name())
+	tx := &Transaction{Hash: "0x01", Status: StatusTypePending}
+	g.settle(context.Background(), []*Transaction{tx}, time.Now())
+	require.Equal(t, StatusTypeLeft, tx.Status)
+}
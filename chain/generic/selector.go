@@ -0,0 +1,140 @@
+package generic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Selector picks values out of a JSON document with a JSONPath-style path: dot separated object keys, [n] array
+// indexes, ['key'] for keys with dots in them, and * or [*] to take every element of an array or value of an object.
+// The leading $ is optional, and an empty path selects the document itself.
+type Selector struct {
+	path  string
+	steps []step
+}
+
+type step struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// ParseSelector parses a path such as "$.result.pending.*.*" or "txs[0].hash".
+func ParseSelector(path string) (Selector, error) {
+	s := Selector{path: path}
+	rest := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			if rest == "" || rest[0] == '.' || rest[0] == '[' {
+				return Selector{}, fmt.Errorf("selector %q: empty key", path)
+			}
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return Selector{}, fmt.Errorf("selector %q: unclosed [", path)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case inner == "*":
+				s.steps = append(s.steps, step{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				s.steps = append(s.steps, step{key: inner[1 : len(inner)-1]})
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return Selector{}, fmt.Errorf("selector %q: invalid index [%s]", path, inner)
+				}
+				s.steps = append(s.steps, step{index: index, isIndex: true})
+			}
+			continue
+		}
+		end := strings.IndexAny(rest, ".[")
+		if end < 0 {
+			end = len(rest)
+		}
+		if key := rest[:end]; key == "*" {
+			s.steps = append(s.steps, step{wildcard: true})
+		} else {
+			s.steps = append(s.steps, step{key: key})
+		}
+		rest = rest[end:]
+	}
+	return s, nil
+}
+
+func (s Selector) String() string {
+	return s.path
+}
+
+// Select returns the values the path leads to, in document order. Object values taken by a wildcard are ordered by key.
+// Missing keys and out of range indexes select nothing.
+func (s Selector) Select(doc interface{}) []interface{} {
+	values := []interface{}{doc}
+	for _, st := range s.steps {
+		var next []interface{}
+		for _, v := range values {
+			switch v := v.(type) {
+			case map[string]interface{}:
+				if st.wildcard {
+					keys := make([]string, 0, len(v))
+					for key := range v {
+						keys = append(keys, key)
+					}
+					slices.Sort(keys)
+					for _, key := range keys {
+						next = append(next, v[key])
+					}
+				} else if child, ok := v[st.key]; ok && !st.isIndex {
+					next = append(next, child)
+				}
+			case []interface{}:
+				if st.wildcard {
+					next = append(next, v...)
+				} else if st.isIndex && st.index < len(v) {
+					next = append(next, v[st.index])
+				}
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+// decodeJSON decodes a JSON document keeping numbers as written, so big integers and hex quantities aren't mangled.
+func decodeJSON(bz []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// formatValue renders a selected value: strings and numbers as written, null as empty, and objects and arrays as JSON.
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		bz, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(bz)
+	}
+}
@@ -0,0 +1,61 @@
+package generic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseSelector(t *testing.T, path string) Selector {
+	t.Helper()
+	s, err := ParseSelector(path)
+	require.NoError(t, err)
+	return s
+}
+
+func TestSelector(t *testing.T) {
+	doc, err := decodeJSON([]byte(`{
+		"pending": {
+			"0xbb": {"1": {"hash": "0x02", "nonce": "0x1"}, "0": {"hash": "0x01", "nonce": "0x0"}},
+			"0xaa": {"7": {"hash": "0x03", "nonce": "0x7"}}
+		},
+		"txs": [{"id": 1, "meta.data": {"ok": true}}, {"id": 2, "tags": ["a", "b"]}],
+		"big": 123456789012345678901234567890
+	}`))
+	require.NoError(t, err)
+
+	cases := map[string][]string{
+		"$.pending.*.*.hash":      {"0x03", "0x01", "0x02"},
+		"pending['0xbb'].0.nonce": {"0x0"},
+		"txs[*].id":               {"1", "2"},
+		"$.txs[1].tags[*]":        {"a", "b"},
+		"txs[0]['meta.data'].ok":  {"true"},
+		"txs[5].id":               nil,
+		"missing.*":               nil,
+		"big":                     {"123456789012345678901234567890"},
+		"txs[1].tags":             {`["a","b"]`},
+	}
+	for path, want := range cases {
+		var got []string
+		for _, v := range mustParseSelector(t, path).Select(doc) {
+			got = append(got, formatValue(v))
+		}
+		require.Equal(t, want, got, path)
+	}
+
+	// the empty path selects the document itself
+	require.Equal(t, []interface{}{"0x01"}, mustParseSelector(t, "").Select("0x01"))
+	require.Equal(t, []interface{}{"0x01"}, mustParseSelector(t, "$").Select("0x01"))
+
+	for _, path := range []string{"a..b", "a[", "a[x]", "a[-1]", "a."} {
+		_, err := ParseSelector(path)
+		require.Error(t, err, path)
+	}
+}
+
+func TestSubstituteHash(t *testing.T) {
+	params := []interface{}{"{hash}", map[string]interface{}{"hash": "0x{hash}", "prove": false}, int64(1)}
+	require.Equal(t, []interface{}{"ab", map[string]interface{}{"hash": "0xab", "prove": false}, int64(1)}, substituteHash(params, "ab"))
+	// the params are left as configured
+	require.Equal(t, "{hash}", params[0])
+}
@@ -0,0 +1,385 @@
+package group
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/technicallyty/xray/chain"
+)
+
+const (
+	// retention is how long a transaction is kept after it was last seen by any node.
+	retention = 2 * time.Minute
+	// gracePeriod is how long a transaction may be known to only some nodes before it is flagged.
+	gracePeriod = 2 * time.Second
+	// minRetryDelay and maxRetryDelay bound the backoff before a failed observer is restarted.
+	minRetryDelay = time.Second
+	maxRetryDelay = 30 * time.Second
+	// lineWidth is the width available to a row inside a box.
+	lineWidth = 58
+)
+
+// Node is a single endpoint in a node group.
+type Node struct {
+	Endpoint string
+	Observer Observer
+}
+
+// observedTx records when each node in the group first saw a transaction.
+type observedTx struct {
+	hash      string
+	firstSeen []time.Time // indexed by node, zero if the node has not seen the tx
+	lastSeen  time.Time   // latest sighting by any node
+}
+
+func (o *observedTx) earliest() time.Time {
+	var earliest time.Time
+	for _, t := range o.firstSeen {
+		if !t.IsZero() && (earliest.IsZero() || t.Before(earliest)) {
+			earliest = t
+		}
+	}
+	return earliest
+}
+
+// missed reports whether a node with a complete view of its mempool has not seen the tx.
+func (o *observedTx) missed(partial []bool) bool {
+	for i, t := range o.firstSeen {
+		if t.IsZero() && !partial[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *observedTx) coverage() int {
+	var n int
+	for _, t := range o.firstSeen {
+		if !t.IsZero() {
+			n++
+		}
+	}
+	return n
+}
+
+// NodeReport summarizes how well a node's mempool matches the rest of the group.
+type NodeReport struct {
+	Seen     int           // txs seen by this node
+	First    int           // txs this node saw before every other node
+	AvgDelay time.Duration // average delay behind the earliest node, over the txs this node saw
+}
+
+// NodeGroupModel compares the mempools of several nodes of the same chain.
+type NodeGroupModel struct {
+	nodes []Node
+	name  string
+
+	mu   sync.RWMutex
+	txs  map[string]*observedTx
+	errs []error // indexed by node, the error the node's observer last failed with, until it sees a tx again
+}
+
+func NewNodeGroupModel(name string, nodes []Node) *NodeGroupModel {
+	return &NodeGroupModel{
+		nodes: nodes,
+		name:  fmt.Sprintf("Node group - %s", name),
+		txs:   make(map[string]*observedTx),
+		errs:  make([]error, len(nodes)),
+	}
+}
+
+var (
+	inMempoolStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("33"))  // bright blue
+	fullStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))  // bright green
+	partialStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("208")) // orange
+	errorStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("196")) // bright red
+
+	boxStyle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder()).
+			Padding(0, 1).
+			Width(60)
+)
+
+func (g *NodeGroupModel) Start(ctx context.Context) {
+	for i := range g.nodes {
+		go g.observe(ctx, i)
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				g.prune(now)
+			}
+		}
+	}()
+}
+
+// observe runs the observer of a node until ctx is done, restarting it with a backoff whenever it fails.
+// The backoff resets once the observer sees a tx again.
+func (g *NodeGroupModel) observe(ctx context.Context, node int) {
+	delay := minRetryDelay
+	for {
+		var recovered bool
+		err := g.nodes[node].Observer.Observe(ctx, func(hash string) {
+			recovered = true
+			g.seen(node, hash, time.Now())
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if recovered {
+			delay = minRetryDelay
+		}
+		if err != nil {
+			g.failed(node, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, maxRetryDelay)
+	}
+}
+
+// failed records the error the observer of node stopped with.
+func (g *NodeGroupModel) failed(node int, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.errs[node] = err
+}
+
+// seen records that node saw hash at t, keeping the first sighting per node and the last sighting overall.
+// A node seeing a tx clears its error.
+func (g *NodeGroupModel) seen(node int, hash string, t time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.errs[node] = nil
+	tx, ok := g.txs[hash]
+	if !ok {
+		tx = &observedTx{hash: hash, firstSeen: make([]time.Time, len(g.nodes))}
+		g.txs[hash] = tx
+	}
+	if tx.firstSeen[node].IsZero() {
+		tx.firstSeen[node] = t
+	}
+	if t.After(tx.lastSeen) {
+		tx.lastSeen = t
+	}
+}
+
+// partialNodes reports, per node, whether its observer only sees part of its mempool at the moment.
+func (g *NodeGroupModel) partialNodes() []bool {
+	partial := make([]bool, len(g.nodes))
+	for i, node := range g.nodes {
+		if observer, ok := node.Observer.(PartialObserver); ok {
+			partial[i] = observer.Partial()
+		}
+	}
+	return partial
+}
+
+func (g *NodeGroupModel) prune(now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for hash, tx := range g.txs {
+		if now.Sub(tx.lastSeen) > retention {
+			delete(g.txs, hash)
+		}
+	}
+}
+
+// reports computes the coverage and propagation delay of every node over the retained txs.
+func (g *NodeGroupModel) reports() []NodeReport {
+	reports := make([]NodeReport, len(g.nodes))
+	totalDelay := make([]time.Duration, len(g.nodes))
+	for _, tx := range g.txs {
+		earliest := tx.earliest()
+		for i, t := range tx.firstSeen {
+			if t.IsZero() {
+				continue
+			}
+			reports[i].Seen++
+			delay := t.Sub(earliest)
+			totalDelay[i] += delay
+			if delay == 0 && tx.coverage() > 1 {
+				reports[i].First++
+			}
+		}
+	}
+	for i := range reports {
+		if reports[i].Seen > 0 {
+			reports[i].AvgDelay = totalDelay[i] / time.Duration(reports[i].Seen)
+		}
+	}
+	return reports
+}
+
+func formatDelay(d time.Duration) string {
+	if d >= time.Second {
+		return fmt.Sprintf("+%.1fs", d.Seconds())
+	}
+	return fmt.Sprintf("+%dms", d.Milliseconds())
+}
+
+// truncate shortens s to at most n characters.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+func shortenHash(hash string) string {
+	if len(hash) <= 10 {
+		return hash
+	}
+	return hash[:6] + "..." + hash[len(hash)-4:]
+}
+
+func (g *NodeGroupModel) Displays() []string {
+	var displays []string
+	const maxTxsPerBox = 8 // leave 2 lines for header and separator
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	now := time.Now()
+	partial := g.partialNodes()
+
+	// NODE REPORT UI
+	{
+		var lines []string
+		lines = append(lines, fmt.Sprintf("Nodes (%d txs seen in last %s)", len(g.txs), retention))
+		lines = append(lines, strings.Repeat("-", 50))
+
+		for i, report := range g.reports() {
+			if err := g.errs[i]; err != nil {
+				line := fmt.Sprintf("n%d | %s | %v", i+1, g.nodes[i].Endpoint, err)
+				lines = append(lines, errorStyle.Render(truncate(line, lineWidth)))
+				continue
+			}
+			// a node listing only part of its mempool misses txs it has, so its coverage means nothing
+			coverage := "  page"
+			if !partial[i] {
+				var pct float64
+				if len(g.txs) > 0 {
+					pct = float64(report.Seen) / float64(len(g.txs)) * 100
+				}
+				coverage = fmt.Sprintf("%5.1f%%", pct)
+			}
+			line := fmt.Sprintf("n%d | cov %s | avg %s | first %d | %s",
+				i+1, coverage, formatDelay(report.AvgDelay), report.First, g.nodes[i].Endpoint)
+			lines = append(lines, inMempoolStyle.Render(truncate(line, lineWidth)))
+		}
+
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+
+		content := strings.Join(lines, "\n")
+		displays = append(displays, boxStyle.Render(content))
+	}
+
+	// DIVERGENT TRANSACTIONS UI
+	{
+		var divergent []*observedTx
+		for _, tx := range g.txs {
+			if tx.missed(partial) && now.Sub(tx.earliest()) > gracePeriod {
+				divergent = append(divergent, tx)
+			}
+		}
+
+		var lines []string
+		lines = append(lines, fmt.Sprintf("Seen by a subset of nodes (%d txs)", len(divergent)))
+		lines = append(lines, strings.Repeat("-", 50))
+
+		lines = append(lines, g.txLines(divergent, maxTxsPerBox)...)
+
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+
+		content := strings.Join(lines, "\n")
+		displays = append(displays, boxStyle.Render(content))
+	}
+
+	// RECENT TRANSACTIONS UI
+	{
+		txs := slices.Collect(maps.Values(g.txs))
+
+		var lines []string
+		lines = append(lines, "Recent Txs (first seen per node)")
+		lines = append(lines, strings.Repeat("-", 50))
+
+		lines = append(lines, g.txLines(txs, maxTxsPerBox)...)
+
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+
+		content := strings.Join(lines, "\n")
+		displays = append(displays, boxStyle.Render(content))
+	}
+
+	return displays
+}
+
+// txLines renders the most recently seen txs, showing how long after the first node every other node saw them.
+func (g *NodeGroupModel) txLines(txs []*observedTx, limit int) []string {
+	slices.SortFunc(txs, func(a, b *observedTx) int {
+		if c := b.earliest().Compare(a.earliest()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.hash, b.hash)
+	})
+	if len(txs) > limit {
+		txs = txs[:limit]
+	}
+
+	var lines []string
+	for _, tx := range txs {
+		earliest := tx.earliest()
+		var delays []string
+		for _, t := range tx.firstSeen {
+			if t.IsZero() {
+				delays = append(delays, "-")
+			} else {
+				delays = append(delays, formatDelay(t.Sub(earliest)))
+			}
+		}
+
+		covered := tx.coverage()
+		line := fmt.Sprintf("%s | %d/%d | %s", shortenHash(tx.hash), covered, len(g.nodes), strings.Join(delays, " "))
+		line = truncate(line, lineWidth)
+		if covered == len(g.nodes) {
+			line = fullStyle.Render(line)
+		} else {
+			line = partialStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (g *NodeGroupModel) Name() string {
+	return g.name
+}
+
+var _ chain.MempoolXray = &NodeGroupModel{}
@@ -0,0 +1,124 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/technicallyty/xray/chain/eth"
+)
+
+func TestNodeGroupReports(t *testing.T) {
+	g := NewNodeGroupModel("test", make([]Node, 3))
+	start := time.Unix(0, 0)
+
+	g.seen(0, "a", start)
+	g.seen(1, "a", start.Add(100*time.Millisecond))
+	g.seen(2, "a", start.Add(300*time.Millisecond))
+	// later sightings do not move the first seen time.
+	g.seen(1, "a", start.Add(time.Second))
+
+	g.seen(1, "b", start)
+	g.seen(0, "b", start.Add(200*time.Millisecond))
+
+	reports := g.reports()
+	require.Equal(t, NodeReport{Seen: 2, First: 1, AvgDelay: 100 * time.Millisecond}, reports[0])
+	require.Equal(t, NodeReport{Seen: 2, First: 1, AvgDelay: 50 * time.Millisecond}, reports[1])
+	require.Equal(t, NodeReport{Seen: 1, First: 0, AvgDelay: 300 * time.Millisecond}, reports[2])
+
+	// txs still seen are kept past the retention of their first sighting, "a" was last seen after a second.
+	g.seen(2, "b", start.Add(time.Minute))
+	g.prune(start.Add(time.Second + retention + time.Millisecond))
+	require.Len(t, g.txs, 1)
+	require.Contains(t, g.txs, "b")
+
+	g.prune(start.Add(time.Minute + retention + time.Millisecond))
+	require.Empty(t, g.txs)
+}
+
+type failingObserver struct {
+	err error
+}
+
+func (o failingObserver) Observe(context.Context, func(string)) error {
+	return o.err
+}
+
+func TestNodeGroupObserverError(t *testing.T) {
+	g := NewNodeGroupModel("test", []Node{{Endpoint: "http://node", Observer: failingObserver{errors.New("connection refused")}}})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go g.observe(ctx, 0)
+
+	require.Eventually(t, func() bool {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+		return g.errs[0] != nil
+	}, time.Second, time.Millisecond)
+	require.Contains(t, g.Displays()[0], "n1 | http://node | connection refused")
+
+	// the error is cleared once the node sees a tx again.
+	g.seen(0, "a", time.Now())
+	require.NotContains(t, g.Displays()[0], "connection refused")
+}
+
+func TestEthObserverError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+	client, err := eth.NewEthereumRPCClient(srv.URL)
+	require.NoError(t, err)
+	observer := NewEthObserver(client, time.Millisecond)
+
+	// the observer gives up once polling failed maxPollFailures times in a row
+	require.Error(t, observer.Observe(context.Background(), func(string) {}))
+
+	g := NewNodeGroupModel("test", []Node{{Endpoint: srv.URL, Observer: observer}})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go g.observe(ctx, 0)
+	require.Eventually(t, func() bool {
+		g.mu.RLock()
+		defer g.mu.RUnlock()
+		return g.errs[0] != nil
+	}, time.Second, time.Millisecond)
+	require.Contains(t, g.Displays()[0], "n1 | "+srv.URL)
+}
+
+type partialObserver struct {
+	failingObserver
+	partial bool
+}
+
+func (o partialObserver) Partial() bool {
+	return o.partial
+}
+
+func TestNodeGroupPartialNode(t *testing.T) {
+	endpoint := "https://cosmos-rpc.polkachu.com:443/" + strings.Repeat("x", 40)
+	g := NewNodeGroupModel("test", []Node{
+		{Endpoint: endpoint, Observer: partialObserver{partial: true}},
+		{Endpoint: endpoint, Observer: partialObserver{}},
+	})
+	start := time.Now().Add(-time.Minute)
+	// beyond the page of n1, so only missed by n1
+	g.seen(1, "offpage", start)
+	// not on the complete view of n2
+	g.seen(0, "missing", start)
+
+	partial := g.partialNodes()
+	require.False(t, g.txs["offpage"].missed(partial))
+	require.True(t, g.txs["missing"].missed(partial))
+
+	displays := g.Displays()
+	require.Contains(t, displays[0], "n1 | cov   page")
+	require.Contains(t, displays[1], "Seen by a subset of nodes (1 txs)")
+	// the long endpoint is truncated rather than wrapped, so the box keeps its 10 lines
+	require.Len(t, strings.Split(displays[0], "\n"), 12)
+}
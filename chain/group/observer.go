@@ -0,0 +1,137 @@
+package group
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/technicallyty/xray/chain"
+	"github.com/technicallyty/xray/chain/cosmos"
+	"github.com/technicallyty/xray/chain/eth"
+)
+
+// Observer reports the transaction hashes a single node has in its mempool.
+type Observer interface {
+	// Observe calls seen for every transaction hash the node reports until ctx is done.
+	// Polling observers may report the same hash more than once.
+	Observe(ctx context.Context, seen func(hash string)) error
+}
+
+// PartialObserver is an Observer that may only see part of its node's mempool, such as the first page of it.
+type PartialObserver interface {
+	Observer
+	// Partial reports whether the last view of the mempool was incomplete, so txs missing from it may still be pending.
+	Partial() bool
+}
+
+// maxPollFailures is how many polls in a row may fail before a polling observer gives up with the error.
+const maxPollFailures = 3
+
+// pollUntilFailing calls fn every pollingRate until ctx is done, or until it failed maxPollFailures times in a row,
+// returning the last error so the group can report it and restart the observer.
+func pollUntilFailing(ctx context.Context, pollingRate time.Duration, fn func() error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var failures int
+	var lastErr error
+	chain.Poll(ctx, pollingRate, func() {
+		if lastErr = fn(); lastErr == nil {
+			failures = 0
+		} else if failures++; failures >= maxPollFailures {
+			cancel()
+		}
+	})
+	if failures >= maxPollFailures {
+		return lastErr
+	}
+	return nil
+}
+
+// EthObserver polls txpool_content on an Ethereum node.
+type EthObserver struct {
+	client      *eth.EthereumRPCClient
+	pollingRate time.Duration
+}
+
+func NewEthObserver(client *eth.EthereumRPCClient, pollingRate time.Duration) *EthObserver {
+	return &EthObserver{client: client, pollingRate: pollingRate}
+}
+
+func (o *EthObserver) Observe(ctx context.Context, seen func(hash string)) error {
+	return pollUntilFailing(ctx, o.pollingRate, func() error {
+		res, err := o.client.TxPoolContent(ctx)
+		if err != nil {
+			return err
+		}
+		for _, txs := range res.ConvertToMap() {
+			for _, tx := range txs {
+				seen(tx.Data.Hash.Hex())
+			}
+		}
+		return nil
+	})
+}
+
+// EthSubObserver subscribes to pending transaction hashes over a websocket connection.
+type EthSubObserver struct {
+	client *rpc.Client
+}
+
+func NewEthSubObserver(client *rpc.Client) *EthSubObserver {
+	return &EthSubObserver{client: client}
+}
+
+func (o *EthSubObserver) Observe(ctx context.Context, seen func(hash string)) error {
+	hashes := make(chan common.Hash, 1000)
+	sub, err := gethclient.New(o.client).SubscribePendingTransactions(ctx, hashes)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			return err
+		case hash := <-hashes:
+			seen(hash.Hex())
+		}
+	}
+}
+
+// CosmosObserver polls unconfirmed_txs on a CometBFT node. It only sees the first page of the mempool.
+type CosmosObserver struct {
+	client      *cosmos.CosmosRPCClient
+	pollingRate time.Duration
+	partial     atomic.Bool
+}
+
+func NewCosmosObserver(client *cosmos.CosmosRPCClient, pollingRate time.Duration) *CosmosObserver {
+	return &CosmosObserver{client: client, pollingRate: pollingRate}
+}
+
+func (o *CosmosObserver) Observe(ctx context.Context, seen func(hash string)) error {
+	return pollUntilFailing(ctx, o.pollingRate, func() error {
+		txs, size, err := o.client.RawMempoolTxs(ctx, cosmos.MempoolPageLimit)
+		if err != nil {
+			return err
+		}
+		o.partial.Store(len(txs) < size.Txs)
+		for _, tx := range txs {
+			seen(cosmos.TxHash(tx))
+		}
+		return nil
+	})
+}
+
+func (o *CosmosObserver) Partial() bool {
+	return o.partial.Load()
+}
+
+var _ PartialObserver = &CosmosObserver{}
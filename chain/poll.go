@@ -0,0 +1,48 @@
+package chain
+
+import (
+	"context"
+	"time"
+)
+
+// MaxCompleted is the number of completed transactions a service keeps for display.
+const MaxCompleted = 50
+
+// Poll calls fn every pollingRate until ctx is done.
+func Poll(ctx context.Context, pollingRate time.Duration, fn func()) {
+	ticker := time.NewTicker(pollingRate)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn()
+		}
+	}
+}
+
+// Removed returns the transactions of prev whose key is not in current, i.e. those that left the mempool since the
+// previous poll.
+func Removed[K comparable, V, W any](prev map[K]V, current map[K]W) []V {
+	var removed []V
+	for key, tx := range prev {
+		if _, ok := current[key]; !ok {
+			removed = append(removed, tx)
+		}
+	}
+	return removed
+}
+
+// TrimCompleted keeps the last MaxCompleted transactions of completed.
+func TrimCompleted[T any](completed []T) []T {
+	if len(completed) > MaxCompleted {
+		return completed[len(completed)-MaxCompleted:]
+	}
+	return completed
+}
+
+// AppendCompleted appends transactions that left the mempool to completed, keeping the last MaxCompleted.
+func AppendCompleted[T any](completed []T, txs ...T) []T {
+	return TrimCompleted(append(completed, txs...))
+}
@@ -0,0 +1,51 @@
+package chain
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoved(t *testing.T) {
+	prev := map[string]int{"a": 1, "b": 2, "c": 3}
+	current := map[string]bool{"b": true, "d": true}
+	removed := Removed(prev, current)
+	slices.Sort(removed)
+	require.Equal(t, []int{1, 3}, removed)
+	require.Empty(t, Removed(map[string]int{}, current))
+}
+
+func TestAppendCompleted(t *testing.T) {
+	var completed []int
+	for i := range MaxCompleted {
+		completed = AppendCompleted(completed, i)
+	}
+	require.Len(t, completed, MaxCompleted)
+	completed = AppendCompleted(completed, MaxCompleted, MaxCompleted+1)
+	require.Len(t, completed, MaxCompleted)
+	require.Equal(t, 2, completed[0])
+	require.Equal(t, MaxCompleted+1, completed[len(completed)-1])
+}
+
+func TestPoll(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int
+	done := make(chan struct{})
+	go func() {
+		Poll(ctx, time.Millisecond, func() {
+			if calls++; calls == 3 {
+				cancel()
+			}
+		})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("poll did not stop when its context was done")
+	}
+	require.Equal(t, 3, calls)
+}
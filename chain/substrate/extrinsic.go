@@ -0,0 +1,270 @@
+package substrate
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/cosmos/btcutil/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+// DefaultSignedExtraBytes is the size of the signed extensions encoded after the tip when unset: the mode byte of
+// CheckMetadataHash, which Polkadot SDK runtimes include since 2024.
+const DefaultSignedExtraBytes = 1
+
+// DefaultSS58Prefix is the generic Substrate address prefix.
+const DefaultSS58Prefix = 42
+
+var errTruncated = errors.New("extrinsic truncated")
+
+// Extrinsic is a pending or included extrinsic with its header decoded. The call arguments are not, since that
+// takes the runtime metadata.
+type Extrinsic struct {
+	// Hash is the blake2b-256 of the encoded extrinsic, as 0x prefixed hex.
+	Hash    string
+	Raw     []byte
+	Version uint8
+	Signed  bool
+	// Signer, Nonce, Tip and Period are set for signed extrinsics. Signer is an SS58 address, or hex for 20 byte accounts.
+	Signer string
+	Nonce  uint64
+	Tip    *big.Int
+	// Period is the number of blocks a mortal extrinsic is valid for, 0 if immortal.
+	Period uint64
+	// Pallet and Call index the call in the runtime metadata.
+	Pallet uint8
+	Call   uint8
+	// DecodeErr says why the header could not be decoded, in which case only Hash and Raw are set.
+	DecodeErr error
+}
+
+// ExtrinsicHash returns the hash a node identifies an extrinsic by.
+func ExtrinsicHash(raw []byte) string {
+	sum := blake2b.Sum256(raw)
+	return "0x" + hex.EncodeToString(sum[:])
+}
+
+// scaleReader reads SCALE encoded values.
+type scaleReader struct {
+	b []byte
+}
+
+func (r *scaleReader) bytes(n int) ([]byte, error) {
+	if n < 0 || len(r.b) < n {
+		return nil, errTruncated
+	}
+	v := r.b[:n]
+	r.b = r.b[n:]
+	return v, nil
+}
+
+func (r *scaleReader) byte() (byte, error) {
+	b, err := r.bytes(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// compact reads a SCALE compact integer.
+func (r *scaleReader) compact() (*big.Int, error) {
+	first, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+	switch first & 0b11 {
+	case 0b00:
+		return big.NewInt(int64(first >> 2)), nil
+	case 0b01:
+		next, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(int64(binary.LittleEndian.Uint16([]byte{first, next}) >> 2)), nil
+	case 0b10:
+		rest, err := r.bytes(3)
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(int64(binary.LittleEndian.Uint32([]byte{first, rest[0], rest[1], rest[2]}) >> 2)), nil
+	default:
+		le, err := r.bytes(int(first>>2) + 4)
+		if err != nil {
+			return nil, err
+		}
+		be := make([]byte, len(le))
+		for i, b := range le {
+			be[len(le)-1-i] = b
+		}
+		return new(big.Int).SetBytes(be), nil
+	}
+}
+
+func (r *scaleReader) compactUint64() (uint64, error) {
+	v, err := r.compact()
+	if err != nil {
+		return 0, err
+	}
+	if !v.IsUint64() {
+		return 0, fmt.Errorf("compact %s overflows uint64", v)
+	}
+	return v.Uint64(), nil
+}
+
+// decodeExtrinsic decodes the header of an encoded extrinsic: its length prefix, version, and for signed v4
+// extrinsics the MultiAddress signer, MultiSignature and the era, nonce and tip signed extensions. extraBytes is the size
+// of the signed extensions after the tip, which depends on the runtime. Signers are encoded with ss58Prefix.
+func decodeExtrinsic(raw []byte, extraBytes int, ss58Prefix uint16) *Extrinsic {
+	ext := &Extrinsic{Hash: ExtrinsicHash(raw), Raw: raw}
+	if err := ext.decode(raw, extraBytes, ss58Prefix); err != nil {
+		ext.DecodeErr = err
+	}
+	return ext
+}
+
+func (ext *Extrinsic) decode(raw []byte, extraBytes int, ss58Prefix uint16) error {
+	r := &scaleReader{b: raw}
+	length, err := r.compactUint64()
+	if err != nil {
+		return err
+	}
+	if length != uint64(len(r.b)) {
+		return fmt.Errorf("length prefix %d but %d bytes", length, len(r.b))
+	}
+	version, err := r.byte()
+	if err != nil {
+		return err
+	}
+	ext.Version = version & 0b0011_1111
+	ext.Signed = version&0b1000_0000 != 0
+	if version&0b0100_0000 != 0 {
+		return fmt.Errorf("general extrinsics (v%d) are not supported", ext.Version)
+	}
+	if ext.Version != 4 && (ext.Signed || ext.Version != 5) {
+		return fmt.Errorf("unsupported extrinsic version %d", ext.Version)
+	}
+
+	if ext.Signed {
+		if ext.Signer, err = readAddress(r, ss58Prefix); err != nil {
+			return fmt.Errorf("signer: %w", err)
+		}
+		if err := skipSignature(r); err != nil {
+			return fmt.Errorf("signature: %w", err)
+		}
+		if ext.Period, err = readEra(r); err != nil {
+			return fmt.Errorf("era: %w", err)
+		}
+		if ext.Nonce, err = r.compactUint64(); err != nil {
+			return fmt.Errorf("nonce: %w", err)
+		}
+		if ext.Tip, err = r.compact(); err != nil {
+			return fmt.Errorf("tip: %w", err)
+		}
+		if _, err := r.bytes(extraBytes); err != nil {
+			return fmt.Errorf("signed extensions: %w", err)
+		}
+	}
+
+	call, err := r.bytes(2)
+	if err != nil {
+		return fmt.Errorf("call: %w", err)
+	}
+	ext.Pallet, ext.Call = call[0], call[1]
+	return nil
+}
+
+// readAddress reads a MultiAddress.
+func readAddress(r *scaleReader, ss58Prefix uint16) (string, error) {
+	kind, err := r.byte()
+	if err != nil {
+		return "", err
+	}
+	switch kind {
+	case 0x00, 0x03: // Id, Address32
+		id, err := r.bytes(32)
+		if err != nil {
+			return "", err
+		}
+		return ss58(id, ss58Prefix), nil
+	case 0x01: // Index
+		index, err := r.compact()
+		if err != nil {
+			return "", err
+		}
+		return "index " + index.String(), nil
+	case 0x02: // Raw
+		n, err := r.compactUint64()
+		if err != nil {
+			return "", err
+		}
+		b, err := r.bytes(int(n))
+		if err != nil {
+			return "", err
+		}
+		return "0x" + hex.EncodeToString(b), nil
+	case 0x04: // Address20
+		b, err := r.bytes(20)
+		if err != nil {
+			return "", err
+		}
+		return "0x" + hex.EncodeToString(b), nil
+	default:
+		return "", fmt.Errorf("unknown address kind %d", kind)
+	}
+}
+
+// skipSignature reads past a MultiSignature.
+func skipSignature(r *scaleReader) error {
+	kind, err := r.byte()
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case 0x00, 0x01: // Ed25519, Sr25519
+		_, err = r.bytes(64)
+	case 0x02: // Ecdsa
+		_, err = r.bytes(65)
+	default:
+		err = fmt.Errorf("unknown signature kind %d", kind)
+	}
+	return err
+}
+
+// readEra reads a transaction era, returning the period of a mortal era and 0 for an immortal one.
+func readEra(r *scaleReader) (uint64, error) {
+	first, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	if first == 0 {
+		return 0, nil
+	}
+	second, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	encoded := binary.LittleEndian.Uint16([]byte{first, second})
+	return 2 << (encoded % (1 << 4)), nil
+}
+
+// ss58 encodes an account id as an SS58 address.
+func ss58(id []byte, prefix uint16) string {
+	var prefixBytes []byte
+	if prefix < 64 {
+		prefixBytes = []byte{byte(prefix)}
+	} else {
+		prefixBytes = []byte{
+			byte((prefix&0b1111_1100)>>2) | 0b0100_0000,
+			byte(prefix>>8) | byte(prefix&0b11)<<6,
+		}
+	}
+	payload := append(prefixBytes, id...)
+	hasher, _ := blake2b.New512(nil)
+	hasher.Write([]byte("SS58PRE"))
+	hasher.Write(payload)
+	checksum := hasher.Sum(nil)[:2]
+	return base58.Encode(append(payload, checksum...))
+}
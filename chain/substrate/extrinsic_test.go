@@ -0,0 +1,80 @@
+package substrate
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var alice, _ = hex.DecodeString("d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+
+// withLength prefixes an encoded extrinsic with its compact length, for extrinsics under 16KB.
+func withLength(body []byte) []byte {
+	n := len(body)
+	if n < 64 {
+		return append([]byte{byte(n << 2)}, body...)
+	}
+	return append([]byte{byte(n<<2) | 0b01, byte(n >> 6)}, body...)
+}
+
+// signedTransfer encodes a signed v4 Balances.transfer_keep_alive from alice with a mortal era.
+func signedTransfer(nonce byte) []byte {
+	body := []byte{0x84, 0x00}
+	body = append(body, alice...)
+	body = append(body, 0x01) // Sr25519
+	body = append(body, make([]byte, 64)...)
+	body = append(body, 0x45, 0x02)                         // mortal era with a period of 64
+	body = append(body, nonce<<2)                           // compact nonce
+	body = append(body, 0x07, 0x00, 0x10, 0xa5, 0xd4, 0xe8) // compact tip of 1e12 in big-integer mode
+	body = append(body, 0x00)                               // CheckMetadataHash disabled
+	body = append(body, 0x05, 0x03, 0xff)                   // Balances.transfer_keep_alive and its (truncated) args
+	return withLength(body)
+}
+
+func TestDecodeExtrinsic(t *testing.T) {
+	raw := signedTransfer(7)
+	ext := decodeExtrinsic(raw, DefaultSignedExtraBytes, DefaultSS58Prefix)
+	require.NoError(t, ext.DecodeErr)
+	require.True(t, ext.Signed)
+	require.Equal(t, uint8(4), ext.Version)
+	require.Equal(t, "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", ext.Signer)
+	require.Equal(t, uint64(7), ext.Nonce)
+	require.Equal(t, big.NewInt(1e12), ext.Tip)
+	require.Equal(t, uint64(64), ext.Period)
+	require.Equal(t, uint8(5), ext.Pallet)
+	require.Equal(t, uint8(3), ext.Call)
+	require.Equal(t, ExtrinsicHash(raw), ext.Hash)
+
+	// Polkadot addresses
+	ext = decodeExtrinsic(raw, DefaultSignedExtraBytes, 0)
+	require.Equal(t, "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5", ext.Signer)
+
+	// unsigned Timestamp.set
+	ext = decodeExtrinsic(withLength([]byte{0x04, 0x03, 0x00, 0x0b}), DefaultSignedExtraBytes, DefaultSS58Prefix)
+	require.NoError(t, ext.DecodeErr)
+	require.False(t, ext.Signed)
+	require.Equal(t, uint8(3), ext.Pallet)
+
+	ext = decodeExtrinsic(raw[:40], DefaultSignedExtraBytes, DefaultSS58Prefix)
+	require.Error(t, ext.DecodeErr)
+	require.Equal(t, ExtrinsicHash(raw[:40]), ext.Hash)
+}
+
+func TestCompact(t *testing.T) {
+	for encoded, want := range map[string]int64{
+		"04":         1,
+		"0101":       64,
+		"feffffff":   1<<30 - 1,
+		"0300000040": 1 << 30,
+	} {
+		b, err := hex.DecodeString(encoded)
+		require.NoError(t, err)
+		r := &scaleReader{b: b}
+		v, err := r.compact()
+		require.NoError(t, err)
+		require.Equal(t, want, v.Int64(), encoded)
+		require.Empty(t, r.b)
+	}
+}
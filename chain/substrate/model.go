@@ -0,0 +1,359 @@
+package substrate
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/technicallyty/xray/chain"
+)
+
+const (
+	// maxBlockScan is the most blocks scanned per poll for included extrinsics.
+	maxBlockScan = 10
+	// includedRetention is the number of blocks included extrinsics are kept for.
+	includedRetention = 50
+)
+
+type StatusType string
+
+const (
+	StatusTypeInPool   StatusType = "in-pool"
+	StatusTypeIncluded StatusType = "included"
+	StatusTypeReplaced StatusType = "replaced"
+	StatusTypeDropped  StatusType = "dropped"
+)
+
+type Transaction struct {
+	Ext           *Extrinsic
+	Status        StatusType
+	FirstSeen     time.Time
+	TimeCompleted time.Time
+	// Height and Index locate the extrinsic in its block once included.
+	Height uint64
+	Index  int
+	// ReplacedBy is the hash of the extrinsic with the same signer and nonce that took its place.
+	ReplacedBy string
+}
+
+// Options configures how extrinsics are decoded.
+type Options struct {
+	// SignedExtraBytes is the size of the runtime's signed extensions after the tip.
+	SignedExtraBytes int
+	// SS58Prefix is the address format signers are rendered with.
+	SS58Prefix uint16
+}
+
+// inclusion is where an extrinsic was included.
+type inclusion struct {
+	hash   string
+	height uint64
+	index  int
+}
+
+// nonceKey identifies the extrinsics that can replace each other: a signer can only have one per nonce.
+type nonceKey struct {
+	signer string
+	nonce  uint64
+}
+
+type SubstrateModel struct {
+	client       *SubstrateRPCClient
+	opts         Options
+	transactions map[string]*Transaction // hash -> transaction
+	completed    []*Transaction
+	name         string
+	pollingRate  time.Duration
+
+	// height is the last block scanned, included the extrinsics found in scanned blocks by hash and by signer nonce.
+	height          uint64
+	included        map[string]inclusion
+	includedByNonce map[nonceKey]inclusion
+	undecodable     int
+}
+
+func NewSubstrateModel(client *SubstrateRPCClient, endpoint string, pollingRate time.Duration, opts Options) *SubstrateModel {
+	return &SubstrateModel{
+		client:          client,
+		opts:            opts,
+		transactions:    make(map[string]*Transaction),
+		completed:       make([]*Transaction, 0),
+		included:        make(map[string]inclusion),
+		includedByNonce: make(map[nonceKey]inclusion),
+		name:            fmt.Sprintf("Substrate - %s", endpoint),
+		pollingRate:     pollingRate,
+	}
+}
+
+var (
+	// Styles for substrate extrinsics
+	inPoolStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("33"))              // bright blue
+	successStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))              // bright green
+	replacedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("208"))             // orange
+	fadedStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Faint(true) // dim gray and faded
+
+	boxStyle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder()).
+			Padding(0, 1).
+			Width(60)
+)
+
+// lineWidth is the width available to a row inside a box.
+const lineWidth = 58
+
+// truncate shortens s to at most n terminal cells.
+func truncate(s string, n int) string {
+	return ansi.Truncate(s, n, "…")
+}
+
+func shortenHash(hash string) string {
+	if len(hash) <= 10 {
+		return hash
+	}
+	return hash[:6] + ".." + hash[len(hash)-4:]
+}
+
+// formatPlanck formats an amount in the chain's smallest unit, scaled like gas.
+func formatPlanck(amount *big.Int) string {
+	if amount == nil {
+		return "0"
+	}
+	f, _ := new(big.Float).SetInt(amount).Float64()
+	switch {
+	case f >= 1e12:
+		return fmt.Sprintf("%.1fT", f/1e12)
+	case f >= 1e9:
+		return fmt.Sprintf("%.1fG", f/1e9)
+	case f >= 1e6:
+		return fmt.Sprintf("%.1fM", f/1e6)
+	case f >= 1e3:
+		return fmt.Sprintf("%.1fK", f/1e3)
+	}
+	return amount.String()
+}
+
+func getStatusPrefix(status StatusType) string {
+	switch status {
+	case StatusTypeIncluded:
+		return "✓ "
+	case StatusTypeReplaced:
+		return "⇄ "
+	case StatusTypeDropped:
+		return "⚠ "
+	default:
+		return ""
+	}
+}
+
+func (s *SubstrateModel) Start(ctx context.Context) {
+	go chain.Poll(ctx, s.pollingRate, func() {
+		pending, err := s.client.PendingExtrinsics(ctx)
+		if err != nil {
+			return
+		}
+		now := time.Now()
+		current := make(map[string]*Transaction, len(pending))
+		for _, raw := range pending {
+			hash := ExtrinsicHash(raw)
+			if prev, ok := s.transactions[hash]; ok {
+				current[hash] = prev
+				continue
+			}
+			ext := decodeExtrinsic(raw, s.opts.SignedExtraBytes, s.opts.SS58Prefix)
+			if ext.DecodeErr != nil {
+				s.undecodable++
+			}
+			current[hash] = &Transaction{Ext: ext, Status: StatusTypeInPool, FirstSeen: now}
+		}
+
+		// blocks are scanned after the pool, so an extrinsic that left it for a block is found in one
+		s.scanBlocks(ctx)
+
+		if removed := chain.Removed(s.transactions, current); len(removed) > 0 {
+			s.settle(removed, current, now)
+			s.completed = chain.AppendCompleted(s.completed, removed...)
+		}
+
+		s.transactions = current
+	})
+}
+
+// scanBlocks records the extrinsics of the blocks produced since the last scan. On the first scan only the best
+// block is read, and at most maxBlockScan blocks are read per poll after that.
+func (s *SubstrateModel) scanBlocks(ctx context.Context) {
+	best, err := s.client.BestHeight(ctx)
+	if err != nil || best <= s.height {
+		return
+	}
+	from := s.height + 1
+	if s.height == 0 {
+		from = best
+	}
+	var heights []uint64
+	for height := from; height <= best && len(heights) < maxBlockScan; height++ {
+		heights = append(heights, height)
+	}
+	blocks, err := s.client.Blocks(ctx, heights)
+	if err != nil {
+		return
+	}
+	for _, block := range blocks {
+		s.recordBlock(block)
+	}
+}
+
+// recordBlock records where the extrinsics of a block were included, and forgets blocks older than includedRetention.
+func (s *SubstrateModel) recordBlock(block *Block) {
+	for i, raw := range block.Extrinsics {
+		at := inclusion{hash: ExtrinsicHash(raw), height: block.Number, index: i}
+		s.included[at.hash] = at
+		if ext := decodeExtrinsic(raw, s.opts.SignedExtraBytes, s.opts.SS58Prefix); ext.DecodeErr == nil && ext.Signed {
+			s.includedByNonce[nonceKey{ext.Signer, ext.Nonce}] = at
+		}
+	}
+	s.height = max(s.height, block.Number)
+	for hash, at := range s.included {
+		if at.height+includedRetention <= s.height {
+			delete(s.included, hash)
+		}
+	}
+	for key, at := range s.includedByNonce {
+		if at.height+includedRetention <= s.height {
+			delete(s.includedByNonce, key)
+		}
+	}
+}
+
+// settle sets the status of extrinsics that left the pool: included if a scanned block has them, replaced if a block
+// or the pool has another extrinsic with the same signer and nonce, and dropped otherwise, e.g. when invalid or stale.
+func (s *SubstrateModel) settle(removed []*Transaction, current map[string]*Transaction, now time.Time) {
+	pending := make(map[nonceKey]string)
+	for hash, tx := range current {
+		if tx.Ext.DecodeErr == nil && tx.Ext.Signed {
+			pending[nonceKey{tx.Ext.Signer, tx.Ext.Nonce}] = hash
+		}
+	}
+	for _, tx := range removed {
+		tx.TimeCompleted = now
+		if at, ok := s.included[tx.Ext.Hash]; ok {
+			tx.Status = StatusTypeIncluded
+			tx.Height, tx.Index = at.height, at.index
+			continue
+		}
+		tx.Status = StatusTypeDropped
+		if tx.Ext.DecodeErr != nil || !tx.Ext.Signed {
+			continue
+		}
+		key := nonceKey{tx.Ext.Signer, tx.Ext.Nonce}
+		if at, ok := s.includedByNonce[key]; ok {
+			tx.Status, tx.ReplacedBy = StatusTypeReplaced, at.hash
+		} else if hash, ok := pending[key]; ok {
+			tx.Status, tx.ReplacedBy = StatusTypeReplaced, hash
+		}
+	}
+}
+
+// callLine renders the columns shared by the pool and completed boxes: hash, pallet:call index, signer and nonce.
+func callLine(ext *Extrinsic) string {
+	line := shortenHash(ext.Hash)
+	if ext.DecodeErr != nil {
+		return fmt.Sprintf("%s | undecodable | %dB", line, len(ext.Raw))
+	}
+	line += fmt.Sprintf(" | %d:%d", ext.Pallet, ext.Call)
+	if !ext.Signed {
+		return line + " | unsigned"
+	}
+	return line + fmt.Sprintf(" | %s | %d", shortenHash(ext.Signer), ext.Nonce)
+}
+
+func (s *SubstrateModel) Displays() []string {
+	var displays []string
+	const maxTxsPerBox = 8 // leave 2 lines for header and separator
+
+	// PENDING EXTRINSICS UI
+	{
+		var txs []*Transaction
+		var signed int
+		for _, tx := range s.transactions {
+			txs = append(txs, tx)
+			if tx.Ext.Signed {
+				signed++
+			}
+		}
+		// highest tip first, the pool orders by priority and the tip raises it
+		slices.SortFunc(txs, func(a, b *Transaction) int {
+			if order := tip(b.Ext).Cmp(tip(a.Ext)); order != 0 {
+				return order
+			}
+			return strings.Compare(a.Ext.Hash, b.Ext.Hash)
+		})
+
+		header := fmt.Sprintf("Pending Extrinsics (%d, %d signed", len(txs), signed)
+		if s.undecodable > 0 {
+			header += fmt.Sprintf(", %d undecodable", s.undecodable)
+		}
+		lines := []string{truncate(header+")", lineWidth), strings.Repeat("-", 50)}
+		for _, tx := range txs[:min(len(txs), maxTxsPerBox)] {
+			line := callLine(tx.Ext)
+			if tx.Ext.Signed && tx.Ext.DecodeErr == nil {
+				line += " | tip " + formatPlanck(tx.Ext.Tip)
+			}
+			line = truncate(line, lineWidth)
+			if tx.Ext.DecodeErr != nil {
+				line = fadedStyle.Render(line)
+			} else {
+				line = inPoolStyle.Render(line)
+			}
+			lines = append(lines, line)
+		}
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+		displays = append(displays, boxStyle.Render(strings.Join(lines, "\n")))
+	}
+
+	// COMPLETED UI
+	{
+		completed := make([]*Transaction, len(s.completed))
+		copy(completed, s.completed)
+		slices.Reverse(completed)
+
+		lines := []string{fmt.Sprintf("Completed (scanned to #%d)", s.height), strings.Repeat("-", 50)}
+		for _, tx := range completed[:min(len(completed), maxTxsPerBox)] {
+			line := getStatusPrefix(tx.Status) + callLine(tx.Ext)
+			switch tx.Status {
+			case StatusTypeIncluded:
+				line = successStyle.Render(truncate(fmt.Sprintf("%s | #%d-%d", line, tx.Height, tx.Index), lineWidth))
+			case StatusTypeReplaced:
+				line = replacedStyle.Render(truncate(fmt.Sprintf("%s | by %s", line, shortenHash(tx.ReplacedBy)), lineWidth))
+			default:
+				line = fadedStyle.Render(truncate(line, lineWidth))
+			}
+			lines = append(lines, line)
+		}
+		for len(lines) < 10 {
+			lines = append(lines, "")
+		}
+		displays = append(displays, boxStyle.Render(strings.Join(lines, "\n")))
+	}
+
+	return displays
+}
+
+func tip(ext *Extrinsic) *big.Int {
+	if ext.Tip == nil {
+		return new(big.Int)
+	}
+	return ext.Tip
+}
+
+func (s *SubstrateModel) Name() string {
+	return s.name
+}
+
+var _ chain.MempoolXray = &SubstrateModel{}
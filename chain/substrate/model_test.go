@@ -0,0 +1,64 @@
+package substrate
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettle(t *testing.T) {
+	s := NewSubstrateModel(nil, "ws://localhost:9944", time.Second, Options{SignedExtraBytes: DefaultSignedExtraBytes, SS58Prefix: DefaultSS58Prefix})
+	pending := func(raw []byte) *Transaction {
+		return &Transaction{Ext: decodeExtrinsic(raw, s.opts.SignedExtraBytes, s.opts.SS58Prefix), Status: StatusTypeInPool}
+	}
+
+	// nonce 1 is included as is, nonce 2 by another extrinsic with the same nonce
+	included, replaced, dropped := pending(signedTransfer(1)), pending(signedTransfer(2)), pending(signedTransfer(3))
+	replacement := signedTransfer(2)
+	replacement[len(replacement)-1] = 0xee
+	s.recordBlock(&Block{Number: 100, Extrinsics: [][]byte{signedTransfer(9), signedTransfer(1), replacement}})
+	require.Equal(t, uint64(100), s.height)
+
+	// nonce 4 is replaced while still pending
+	queued := pending(signedTransfer(4))
+	queuedReplacement := signedTransfer(4)
+	queuedReplacement[len(queuedReplacement)-1] = 0xdd
+	current := map[string]*Transaction{ExtrinsicHash(queuedReplacement): pending(queuedReplacement)}
+
+	s.settle([]*Transaction{included, replaced, dropped, queued}, current, time.Now())
+	require.Equal(t, StatusTypeIncluded, included.Status)
+	require.Equal(t, uint64(100), included.Height)
+	require.Equal(t, 1, included.Index)
+	require.Equal(t, StatusTypeReplaced, replaced.Status)
+	require.Equal(t, ExtrinsicHash(replacement), replaced.ReplacedBy)
+	require.Equal(t, StatusTypeDropped, dropped.Status)
+	require.Equal(t, StatusTypeReplaced, queued.Status)
+	require.Equal(t, ExtrinsicHash(queuedReplacement), queued.ReplacedBy)
+
+	// blocks beyond the retention are forgotten
+	s.recordBlock(&Block{Number: 100 + includedRetention})
+	require.Empty(t, s.included)
+	require.Empty(t, s.includedByNonce)
+}
+
+func TestCallLine(t *testing.T) {
+	ext := decodeExtrinsic(signedTransfer(7), DefaultSignedExtraBytes, DefaultSS58Prefix)
+	require.Equal(t, shortenHash(ext.Hash)+" | 5:3 | 5Grwva..utQY | 7", callLine(ext))
+	require.Equal(t, "1.0T", formatPlanck(ext.Tip))
+}
+
+func TestDisplaysWidth(t *testing.T) {
+	s := NewSubstrateModel(nil, "ws://localhost:9944", time.Second, Options{SignedExtraBytes: DefaultSignedExtraBytes, SS58Prefix: DefaultSS58Prefix})
+	ext := decodeExtrinsic(signedTransfer(7), DefaultSignedExtraBytes, DefaultSS58Prefix)
+	ext.Pallet, ext.Nonce, ext.Tip = 10, 123456, big.NewInt(123456789012345)
+	s.transactions[ext.Hash] = &Transaction{Ext: ext, Status: StatusTypeInPool}
+	s.completed = append(s.completed, &Transaction{Ext: ext, Status: StatusTypeIncluded, Height: 28123456, Index: 12})
+	s.height = 28123456
+	for _, display := range s.Displays() {
+		// 10 lines and the border, no row wrapped
+		require.Len(t, strings.Split(display, "\n"), 12)
+	}
+}
@@ -0,0 +1,99 @@
+package substrate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// SubstrateRPCClient wraps the JSON-RPC client of a Substrate node.
+type SubstrateRPCClient struct {
+	client *rpc.Client
+}
+
+// NewSubstrateRPCClient creates a client over http or websocket.
+func NewSubstrateRPCClient(address string) (*SubstrateRPCClient, error) {
+	client, err := rpc.Dial(address)
+	if err != nil {
+		return nil, err
+	}
+	return &SubstrateRPCClient{client: client}, nil
+}
+
+// PendingExtrinsics calls author_pendingExtrinsics, returning the encoded extrinsics of the transaction pool.
+func (c *SubstrateRPCClient) PendingExtrinsics(ctx context.Context) ([][]byte, error) {
+	var result []hexutil.Bytes
+	if err := c.client.CallContext(ctx, &result, "author_pendingExtrinsics"); err != nil {
+		return nil, err
+	}
+	extrinsics := make([][]byte, len(result))
+	for i, ext := range result {
+		extrinsics[i] = ext
+	}
+	return extrinsics, nil
+}
+
+type header struct {
+	Number string `json:"number"`
+}
+
+// BestHeight calls chain_getHeader for the number of the best block.
+func (c *SubstrateRPCClient) BestHeight(ctx context.Context) (uint64, error) {
+	var h header
+	if err := c.client.CallContext(ctx, &h, "chain_getHeader"); err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(h.Number, 0, 64)
+}
+
+// Block is a block with its encoded extrinsics.
+type Block struct {
+	Number     uint64
+	Hash       string
+	Extrinsics [][]byte
+}
+
+// Blocks fetches the blocks of the given heights in two batches, chain_getBlockHash then chain_getBlock.
+func (c *SubstrateRPCClient) Blocks(ctx context.Context, heights []uint64) ([]*Block, error) {
+	hashes := make([]string, len(heights))
+	elems := make([]rpc.BatchElem, len(heights))
+	for i, height := range heights {
+		elems[i] = rpc.BatchElem{Method: "chain_getBlockHash", Args: []interface{}{height}, Result: &hashes[i]}
+	}
+	if err := c.client.BatchCallContext(ctx, elems); err != nil {
+		return nil, err
+	}
+	for i, elem := range elems {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("failed to get hash of block %d: %w", heights[i], elem.Error)
+		}
+	}
+
+	type signedBlock struct {
+		Block struct {
+			Extrinsics []hexutil.Bytes `json:"extrinsics"`
+		} `json:"block"`
+	}
+	results := make([]signedBlock, len(heights))
+	for i := range heights {
+		elems[i] = rpc.BatchElem{Method: "chain_getBlock", Args: []interface{}{hashes[i]}, Result: &results[i]}
+	}
+	if err := c.client.BatchCallContext(ctx, elems); err != nil {
+		return nil, err
+	}
+	blocks := make([]*Block, len(heights))
+	for i, elem := range elems {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("failed to get block %d: %w", heights[i], elem.Error)
+		}
+		block := &Block{Number: heights[i], Hash: hashes[i]}
+		for _, ext := range results[i].Block.Extrinsics {
+			block.Extrinsics = append(block.Extrinsics, ext)
+		}
+		blocks[i] = block
+	}
+	return blocks, nil
+}
@@ -8,15 +8,19 @@ import (
 )
 
 type Config struct {
-	ChainConfigs []ChainConfig `toml:"chain_configs"`
+	ChainConfigs []ChainConfig     `toml:"chain_configs"`
+	NodeGroups   []NodeGroupConfig `toml:"node_groups"`
 }
 
 type ChainType string
 
 const (
-	ChainTypeCosmos   ChainType = "cosmos"
-	ChainTypeEthereum ChainType = "eth"
-	ChainTypeETHSub   ChainType = "eth_sub"
+	ChainTypeCosmos    ChainType = "cosmos"
+	ChainTypeEthereum  ChainType = "eth"
+	ChainTypeETHSub    ChainType = "eth_sub"
+	ChainTypeBitcoin   ChainType = "bitcoin"
+	ChainTypeSubstrate ChainType = "substrate"
+	ChainTypeGeneric   ChainType = "generic"
 )
 
 var (
@@ -28,8 +32,76 @@ var (
 )
 
 type ChainConfig struct {
+	CType       ChainType        `toml:"chain_type"`
+	RPCEndpoint string           `toml:"rpc_endpoint"`
+	PollingRate time.Duration    `toml:"polling_rate"`
+	Simulation  SimulationConfig `toml:"simulation"`
+	// Bech32Prefix is the account address prefix of a cosmos chain, "cosmos" if unset.
+	Bech32Prefix string `toml:"bech32_prefix"`
+	// MempoolSort orders the cosmos mempool box by "hash" (default) or "gas_price".
+	MempoolSort string `toml:"mempool_sort"`
+	// MempoolTTLNumBlocks and MempoolTTLDuration mirror the cosmos node's mempool ttl settings, used to tell TTL evictions apart.
+	MempoolTTLNumBlocks int64         `toml:"mempool_ttl_num_blocks"`
+	MempoolTTLDuration  time.Duration `toml:"mempool_ttl_duration"`
+	// PriorityMempool is set for cosmos nodes whose mempool orders txs by priority instead of arrival.
+	PriorityMempool bool `toml:"priority_mempool"`
+	// ExpiryWarnBlocks flags cosmos mempool txs this many blocks before their timeout, 5 if unset.
+	ExpiryWarnBlocks int64 `toml:"expiry_warn_blocks"`
+	// MemoWatch are regular expressions matched against cosmos tx memos, to highlight and follow matching txs.
+	MemoWatch []string `toml:"memo_watch"`
+	// Lanes are the Block SDK lanes of a cosmos chain in priority order, a single MEV lane if unset.
+	Lanes []LaneConfig `toml:"lanes"`
+	// SS58Prefix is the address format of a substrate chain, 42 if unset. 0 is Polkadot.
+	SS58Prefix *uint16 `toml:"ss58_prefix"`
+	// SignedExtraBytes is the size of a substrate runtime's signed extensions after the tip, 1 if unset.
+	SignedExtraBytes *int `toml:"signed_extra_bytes"`
+	// Generic describes the RPC method and selectors of a generic chain.
+	Generic GenericConfig `toml:"generic"`
+}
+
+// GenericConfig reads the mempool of a chain without a dedicated adapter over JSON-RPC. Paths are JSONPath-style
+// selectors such as "$.pending.*.*".
+type GenericConfig struct {
+	Name   string        `toml:"name"`
+	Method string        `toml:"method"`
+	Params []interface{} `toml:"params"`
+	// Txs selects the txs from the result, Hash and the field paths select from each tx.
+	Txs    string               `toml:"txs"`
+	Hash   string               `toml:"hash"`
+	Fields []GenericFieldConfig `toml:"fields"`
+	// StatusMethod is called for txs that left the mempool, with "{hash}" in StatusParams replaced by the tx hash.
+	StatusMethod  string        `toml:"status_method"`
+	StatusParams  []interface{} `toml:"status_params"`
+	StatusPath    string        `toml:"status_path"`
+	SuccessValues []string      `toml:"success_values"`
+}
+
+type GenericFieldConfig struct {
+	Name string `toml:"name"`
+	Path string `toml:"path"`
+}
+
+// LaneConfig matches cosmos txs to a Block SDK lane by message type, ending in * to match by prefix, and by signer.
+type LaneConfig struct {
+	Name     string   `toml:"name"`
+	MsgTypes []string `toml:"msg_types"`
+	Signers  []string `toml:"signers"`
+}
+
+// SimulationConfig enables pre-inclusion failure prediction for eth chains.
+type SimulationConfig struct {
+	Enabled bool `toml:"enabled"`
+	// Watch limits simulation to txs from or to these accounts or contracts.
+	Watch []string `toml:"watch"`
+	// Trace uses debug_traceCall to find the call that reverted.
+	Trace bool `toml:"trace"`
+}
+
+// NodeGroupConfig groups several endpoints of the same chain into a single view that compares their mempools.
+type NodeGroupConfig struct {
+	Name        string        `toml:"name"`
 	CType       ChainType     `toml:"chain_type"`
-	RPCEndpoint string        `toml:"rpc_endpoint"`
+	Endpoints   []string      `toml:"endpoints"`
 	PollingRate time.Duration `toml:"polling_rate"`
 }
 
@@ -3,13 +3,19 @@ package main
 import (
 	"flag"
 	"log"
+	"regexp"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/technicallyty/xray/chain"
+	"github.com/technicallyty/xray/chain/bitcoin"
 	"github.com/technicallyty/xray/chain/cosmos"
 	"github.com/technicallyty/xray/chain/eth"
 	subscriber "github.com/technicallyty/xray/chain/eth/subsriber"
+	"github.com/technicallyty/xray/chain/generic"
+	"github.com/technicallyty/xray/chain/group"
+	"github.com/technicallyty/xray/chain/substrate"
 )
 
 func main() {
@@ -46,13 +52,51 @@ func getXrays(cfg Config) []chain.MempoolXray {
 			if err != nil {
 				log.Fatal(err)
 			}
-			xrays = append(xrays, cosmos.NewCosmosModel(client, c.RPCEndpoint, c.PollingRate))
+			var memoWatch []*regexp.Regexp
+			for _, pattern := range c.MemoWatch {
+				rule, err := regexp.Compile(pattern)
+				if err != nil {
+					log.Fatalf("invalid memo watch pattern %q: %v", pattern, err)
+				}
+				memoWatch = append(memoWatch, rule)
+			}
+			sort := cosmos.MempoolSort(c.MempoolSort)
+			switch sort {
+			case "", cosmos.MempoolSortHash, cosmos.MempoolSortGasPrice:
+			default:
+				log.Fatalf("invalid mempool sort %q, expected %q or %q", c.MempoolSort, cosmos.MempoolSortHash, cosmos.MempoolSortGasPrice)
+			}
+			var lanes []cosmos.Lane
+			for _, lane := range c.Lanes {
+				lanes = append(lanes, cosmos.Lane{Name: lane.Name, MsgTypes: lane.MsgTypes, Signers: lane.Signers})
+			}
+			model, err := cosmos.NewCosmosModel(client, c.RPCEndpoint, c.PollingRate, cosmos.Options{
+				Bech32Prefix:       c.Bech32Prefix,
+				MempoolSort:        sort,
+				MempoolTTLBlocks:   c.MempoolTTLNumBlocks,
+				MempoolTTLDuration: c.MempoolTTLDuration,
+				PriorityMempool:    c.PriorityMempool,
+				ExpiryWarnBlocks:   c.ExpiryWarnBlocks,
+				MemoWatch:          memoWatch,
+				Lanes:              lanes,
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+			xrays = append(xrays, model)
 		case ChainTypeEthereum:
 			client, err := eth.NewEthereumRPCClient(c.RPCEndpoint)
 			if err != nil {
 				log.Fatal(err)
 			}
-			xrays = append(xrays, eth.NewEthModel(client, c.RPCEndpoint, c.PollingRate))
+			simulation := eth.SimulationConfig{Enabled: c.Simulation.Enabled, Trace: c.Simulation.Trace}
+			for _, addr := range c.Simulation.Watch {
+				if !common.IsHexAddress(addr) {
+					log.Fatalf("invalid simulation watch address %q", addr)
+				}
+				simulation.Watch = append(simulation.Watch, common.HexToAddress(addr))
+			}
+			xrays = append(xrays, eth.NewEthModel(client, c.RPCEndpoint, c.PollingRate, simulation))
 		case ChainTypeETHSub:
 			client, err := subscriber.NewRPCClient(c.RPCEndpoint)
 			if err != nil {
@@ -60,7 +104,84 @@ func getXrays(cfg Config) []chain.MempoolXray {
 			}
 			model := subscriber.NewSubModel(client, "eth", 10)
 			xrays = append(xrays, model)
+		case ChainTypeBitcoin:
+			client, err := bitcoin.NewBitcoinRPCClient(c.RPCEndpoint)
+			if err != nil {
+				log.Fatal(err)
+			}
+			xrays = append(xrays, bitcoin.NewBitcoinModel(client, c.RPCEndpoint, c.PollingRate))
+		case ChainTypeSubstrate:
+			client, err := substrate.NewSubstrateRPCClient(c.RPCEndpoint)
+			if err != nil {
+				log.Fatal(err)
+			}
+			opts := substrate.Options{SignedExtraBytes: substrate.DefaultSignedExtraBytes, SS58Prefix: substrate.DefaultSS58Prefix}
+			if c.SignedExtraBytes != nil {
+				opts.SignedExtraBytes = *c.SignedExtraBytes
+			}
+			if c.SS58Prefix != nil {
+				opts.SS58Prefix = *c.SS58Prefix
+			}
+			xrays = append(xrays, substrate.NewSubstrateModel(client, c.RPCEndpoint, c.PollingRate, opts))
+		case ChainTypeGeneric:
+			client, err := generic.NewGenericRPCClient(c.RPCEndpoint)
+			if err != nil {
+				log.Fatal(err)
+			}
+			cfg := generic.Config{
+				Name:          c.Generic.Name,
+				Method:        c.Generic.Method,
+				Params:        c.Generic.Params,
+				Txs:           c.Generic.Txs,
+				Hash:          c.Generic.Hash,
+				StatusMethod:  c.Generic.StatusMethod,
+				StatusParams:  c.Generic.StatusParams,
+				StatusPath:    c.Generic.StatusPath,
+				SuccessValues: c.Generic.SuccessValues,
+			}
+			for _, f := range c.Generic.Fields {
+				cfg.Fields = append(cfg.Fields, generic.Field{Name: f.Name, Path: f.Path})
+			}
+			model, err := generic.NewGenericModel(client, c.RPCEndpoint, c.PollingRate, cfg)
+			if err != nil {
+				log.Fatalf("generic chain %s: %v", c.RPCEndpoint, err)
+			}
+			xrays = append(xrays, model)
 		}
 	}
+	for _, g := range cfg.NodeGroups {
+		xrays = append(xrays, getNodeGroup(g))
+	}
 	return xrays
 }
+
+func getNodeGroup(cfg NodeGroupConfig) chain.MempoolXray {
+	nodes := make([]group.Node, 0, len(cfg.Endpoints))
+	for _, endpoint := range cfg.Endpoints {
+		var observer group.Observer
+		switch cfg.CType {
+		case ChainTypeCosmos:
+			client, err := cosmos.NewCosmosRPCClient(endpoint)
+			if err != nil {
+				log.Fatal(err)
+			}
+			observer = group.NewCosmosObserver(client, cfg.PollingRate)
+		case ChainTypeEthereum:
+			client, err := eth.NewEthereumRPCClient(endpoint)
+			if err != nil {
+				log.Fatal(err)
+			}
+			observer = group.NewEthObserver(client, cfg.PollingRate)
+		case ChainTypeETHSub:
+			client, err := subscriber.NewRPCClient(endpoint)
+			if err != nil {
+				log.Fatal(err)
+			}
+			observer = group.NewEthSubObserver(client)
+		default:
+			log.Fatalf("node group %s: unsupported chain type %q", cfg.Name, cfg.CType)
+		}
+		nodes = append(nodes, group.Node{Endpoint: endpoint, Observer: observer})
+	}
+	return group.NewNodeGroupModel(cfg.Name, nodes)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -25,6 +26,9 @@ type Model struct {
 	ready       bool
 	content     string // Cache content to avoid resetting viewport
 	cancel      context.CancelFunc
+	// searching is set while a search is typed, search is applied to the xrays that can filter.
+	searching bool
+	search    string
 }
 
 func (m *Model) Init() tea.Cmd {
@@ -60,6 +64,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.updateContent()
 		}
 	case tea.KeyMsg: // handles keypress
+		if m.searching {
+			m.updateSearch(msg)
+			return m, nil
+		}
 		switch msg.String() {
 		case "q", "esc", "ctrl+c":
 			m.cancel()
@@ -72,6 +80,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.viewport.HalfPageUp()
 		case "pgdown", "f":
 			m.viewport.HalfPageDown()
+		case "/":
+			m.searching = true
 		}
 	case tickMsg: // handles updating UI
 		// Update content when data changes
@@ -85,6 +95,32 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// updateSearch edits the search as it is typed and applies it right away. Enter keeps it, esc clears it.
+func (m *Model) updateSearch(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.searching = false
+		return
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.searching = false
+		m.search = ""
+	case tea.KeyBackspace:
+		if runes := []rune(m.search); len(runes) > 0 {
+			m.search = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.search += string(msg.Runes)
+	default:
+		return
+	}
+	for _, xray := range m.xrays {
+		if filterable, ok := xray.(chain.Filterable); ok {
+			filterable.SetFilter(m.search)
+		}
+	}
+	m.updateContent()
+}
+
 var (
 	titleStyle        = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212")).Padding(0, 1)
 	sectionTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("99")).Padding(0, 0, 1, 0)
@@ -149,7 +185,13 @@ func (m Model) View() string {
 	header := titleStyle.Render("🌐 Mempool X-Ray") + "\n\n"
 
 	// Footer
-	footer := helpStyle.Render("↑/↓: scroll • PgUp/PgDn: half page • q: quit")
+	footer := helpStyle.Render("↑/↓: scroll • PgUp/PgDn: half page • /: search • q: quit")
+	switch {
+	case m.searching:
+		footer = "/" + m.search + "▏" + helpStyle.Render("  enter: keep • esc: clear")
+	case m.search != "":
+		footer = helpStyle.Render(fmt.Sprintf("search %q • ", m.search)) + footer
+	}
 
 	return header + m.viewport.View() + "\n" + footer
 }